@@ -0,0 +1,102 @@
+package xerrors
+
+import "time"
+
+// Builder builds XErr with fluent API, e.g.
+//
+//	xerrors.Build("user not found").Code("user_not_found").Status(404).Cause(err).Extra("id", id).Err()
+//
+// Builder is immutable, each method returns new Builder, so it can be stored in a package-level variable
+// and reused as a definition of the error. Each Err call returns new independent XErr.
+type Builder struct {
+	msg  string
+	opts []XErrOpt
+}
+
+// Build returns new Builder of XErr with the message.
+func Build(msg string) Builder {
+	return Builder{msg: msg}
+}
+
+// BuildFrom returns new Builder initialized with fields of xErr, e.g. a predefined error.
+// Extra maps are copied, so xErr is never modified.
+func BuildFrom(xErr XError) Builder {
+	if IsNil(xErr) {
+		return Builder{}
+	}
+
+	b := Build(xErr.GetMessage()).
+		Code(CodeKey(xErr)).
+		Description(xErr.GetDescription()).
+		Kind(xErr.GetKind()).
+		Options(MergeExtra(xErr.GetExtra()), MergeInternalExtra(xErr.GetInternalExtra()))
+
+	if x, ok := xErr.(*XErr); !ok || x.Severity != 0 {
+		b = b.Severity(xErr.GetSeverity())
+	}
+
+	if r, ok := xErr.(interface{ Retryable() bool }); ok && r.Retryable() {
+		b = b.Retryable(true)
+	}
+
+	if r, ok := xErr.(interface{ RetryAfter() time.Duration }); ok && r.RetryAfter() > 0 {
+		b = b.RetryAfter(r.RetryAfter())
+	}
+
+	return b
+}
+
+// Message sets error message.
+func (b Builder) Message(msg string) Builder {
+	b.msg = msg
+
+	return b
+}
+
+// Code sets error code, see WithCode.
+func (b Builder) Code(code string) Builder { return b.with(WithCode(code)) }
+
+// Description sets error description, see WithDescription.
+func (b Builder) Description(descr string) Builder { return b.with(WithDescription(descr)) }
+
+// Severity sets error severity, see WithSeverity.
+func (b Builder) Severity(s Severity) Builder { return b.with(WithSeverity(s)) }
+
+// Kind sets error kind, see WithKind.
+func (b Builder) Kind(kind Kind) Builder { return b.with(WithKind(kind)) }
+
+// Retryable marks error as retryable or not, see WithRetryable.
+func (b Builder) Retryable(retryable bool) Builder { return b.with(WithRetryable(retryable)) }
+
+// RetryAfter sets delay before the next retry, see WithRetryAfter.
+func (b Builder) RetryAfter(d time.Duration) Builder { return b.with(WithRetryAfter(d)) }
+
+// Status sets HTTP status code stored in extra under the "http_code" key.
+// Unlike xhttp constructors it doesn't derive severity and kind from the status code.
+func (b Builder) Status(code int) Builder { return b.with(WithExtraField("http_code", code)) }
+
+// Cause sets the cause stored in internal extra under the "error" key.
+func (b Builder) Cause(err error) Builder { return b.with(WithInternalExtraField("error", err)) }
+
+// Extra sets extra value under key, see WithExtraField.
+func (b Builder) Extra(key string, v interface{}) Builder { return b.with(WithExtraField(key, v)) }
+
+// Internal sets internal extra value under key, see WithInternalExtraField.
+func (b Builder) Internal(key string, v interface{}) Builder {
+	return b.with(WithInternalExtraField(key, v))
+}
+
+// Options adds arbitrary options applied in order with the other builder methods.
+func (b Builder) Options(opts ...XErrOpt) Builder { return b.with(opts...) }
+
+// Err returns new XErr created with New.
+func (b Builder) Err() *XErr {
+	return New(b.msg, b.opts...)
+}
+
+// with returns copy of b with opts added, the options slice is copied, so builders never share it.
+func (b Builder) with(opts ...XErrOpt) Builder {
+	b.opts = append(append(make([]XErrOpt, 0, len(b.opts)+len(opts)), b.opts...), opts...)
+
+	return b
+}
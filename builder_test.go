@@ -0,0 +1,141 @@
+// nolint:funlen,goerr113
+package xerrors
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBuilder_Err(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("cause")
+
+	tests := []struct {
+		name    string
+		builder Builder
+		want    *XErr
+	}{
+		{
+			name:    "message only",
+			builder: Build("test msg"),
+			want:    &XErr{Message: "test msg"},
+		},
+		{
+			name: "all fields",
+			builder: Build("test msg").
+				Code("test_code").
+				Description("test descr").
+				Severity(SeverityWarning).
+				Kind(KindNotFound).
+				Status(404).
+				Cause(cause).
+				Extra("id", 42).
+				Internal("query", "SELECT").
+				Message("rewrite message"),
+			want: &XErr{
+				Code:          "test_code",
+				Message:       "rewrite message",
+				Description:   "test descr",
+				Severity:      SeverityWarning,
+				Kind:          KindNotFound,
+				Extra:         map[string]interface{}{"http_code": 404, "id": 42},
+				InternalExtra: map[string]interface{}{"error": cause, "query": "SELECT"},
+			},
+		},
+		{
+			name:    "retry after",
+			builder: Build("test msg").RetryAfter(time.Second),
+			want:    &XErr{Message: "test msg", retryable: true, retryAfter: time.Second},
+		},
+		{
+			name:    "options in order",
+			builder: Build("test msg").Extra("a", 1).Options(WithExtra(map[string]interface{}{"b": 2})).Extra("c", 3),
+			want:    &XErr{Message: "test msg", Extra: map[string]interface{}{"b": 2, "c": 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.builder.Err(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Err() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuilder_Reuse(t *testing.T) {
+	t.Parallel()
+
+	base := Build("user not found").Code("user_not_found").Status(404)
+
+	first := base.Extra("id", 1)
+	second := base.Extra("id", 2)
+
+	xErr1, xErr2 := first.Err(), second.Err()
+
+	if got := xErr1.Extra["id"]; got != 1 {
+		t.Errorf("first.Err().Extra[id] = %v, want 1", got)
+	}
+
+	if got := xErr2.Extra["id"]; got != 2 {
+		t.Errorf("second.Err().Extra[id] = %v, want 2", got)
+	}
+
+	xErr3 := base.Err()
+	xErr3.Extra["http_code"] = 500
+
+	if got := base.Err().Extra; !reflect.DeepEqual(got, map[string]interface{}{"http_code": 404}) {
+		t.Errorf("base.Err().Extra = %v after modifying previous instance", got)
+	}
+}
+
+func TestBuildFrom(t *testing.T) {
+	t.Parallel()
+
+	proto := New("user not found",
+		WithCode("user_not_found"),
+		WithDescription("test descr"),
+		WithSeverity(SeverityInfo),
+		WithKind(KindNotFound),
+		WithRetryAfter(time.Second),
+		WithExtra(map[string]interface{}{"http_code": 404}),
+		WithInternalExtra(map[string]interface{}{"query": "SELECT"}),
+	)
+
+	got := BuildFrom(proto).Extra("id", 42).Err()
+
+	want := &XErr{
+		Code:          "user_not_found",
+		Message:       "user not found",
+		Description:   "test descr",
+		Severity:      SeverityInfo,
+		Kind:          KindNotFound,
+		Extra:         map[string]interface{}{"http_code": 404, "id": 42},
+		InternalExtra: map[string]interface{}{"query": "SELECT"},
+		retryable:     true,
+		retryAfter:    time.Second,
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BuildFrom().Err() = %v, want %v", got, want)
+	}
+
+	if len(proto.Extra) != 1 {
+		t.Errorf("prototype extra was modified: %v", proto.Extra)
+	}
+
+	if got := BuildFrom(New("test msg")).Err(); got.Severity != 0 {
+		t.Errorf("BuildFrom().Err().Severity = %v, want default", got.Severity)
+	}
+
+	if got := BuildFrom(nil).Err(); !reflect.DeepEqual(got, &XErr{}) {
+		t.Errorf("BuildFrom(nil).Err() = %v, want empty XErr", got)
+	}
+}
@@ -0,0 +1,147 @@
+package xerrors
+
+import (
+	"sort"
+	"sync"
+)
+
+// CollectorOpt represents option for Collector constructor NewCollector.
+type CollectorOpt func(c *Collector)
+
+// WithConcurrency limits the number of functions launched by Collector.Go that run at the same time.
+// Non-positive n means no limit.
+func WithConcurrency(n int) CollectorOpt {
+	return func(c *Collector) {
+		if n > 0 {
+			c.sem = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithFailFast makes Collector skip functions that haven't started yet once the first error is collected.
+func WithFailFast() CollectorOpt { return func(c *Collector) { c.failFast = true } }
+
+// Collector is a concurrency-safe collector of XError.
+// Errors are returned by Wait in the order Add and Go were called, regardless of completion order.
+type Collector struct {
+	wg  sync.WaitGroup
+	sem chan struct{}
+
+	mu       sync.Mutex
+	failFast bool
+	failed   bool
+	seq      int
+	errs     []collectedErr
+}
+
+type collectedErr struct {
+	seq int
+	err XError
+}
+
+// NewCollector - constructor for Collector with options, returns new *Collector.
+func NewCollector(opts ...CollectorOpt) *Collector {
+	c := &Collector{}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Add adds XError to collection, nil errors are skipped. It's safe to call Add from multiple goroutines.
+func (c *Collector) Add(xerrs ...XError) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, xErr := range xerrs {
+		c.add(c.next(), xErr)
+	}
+}
+
+// Go calls fn in a new goroutine and collects the returned XError.
+// If concurrency limit is set, Go blocks until fn can be started.
+// In fail-fast mode fn is not called if an error has already been collected.
+func (c *Collector) Go(fn func() XError) {
+	c.mu.Lock()
+	seq := c.next()
+	c.mu.Unlock()
+
+	if c.isFailed() {
+		return
+	}
+
+	if c.sem != nil {
+		c.sem <- struct{}{}
+	}
+
+	c.wg.Add(1)
+
+	go func() {
+		defer c.wg.Done()
+		defer c.release()
+
+		if c.isFailed() {
+			return
+		}
+
+		xErr := fn()
+
+		c.mu.Lock()
+		c.add(seq, xErr)
+		c.mu.Unlock()
+	}()
+}
+
+// Wait waits for all functions launched by Go and returns collected errors,
+// or nil if there are no errors.
+func (c *Collector) Wait() *XErrs {
+	c.wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.errs) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(c.errs, func(i, j int) bool { return c.errs[i].seq < c.errs[j].seq })
+
+	xErrs := NewXErrsWithLen(0, len(c.errs))
+	for i := range c.errs {
+		xErrs.Add(c.errs[i].err)
+	}
+
+	return xErrs
+}
+
+// next returns next sequence number, must be called with c.mu held.
+func (c *Collector) next() int {
+	c.seq++
+
+	return c.seq
+}
+
+// add appends not nil xErr to collection, must be called with c.mu held.
+func (c *Collector) add(seq int, xErr XError) {
+	if IsNil(xErr) {
+		return
+	}
+
+	c.errs = append(c.errs, collectedErr{seq: seq, err: xErr})
+	c.failed = true
+}
+
+func (c *Collector) isFailed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.failFast && c.failed
+}
+
+func (c *Collector) release() {
+	if c.sem != nil {
+		<-c.sem
+	}
+}
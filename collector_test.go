@@ -0,0 +1,171 @@
+// nolint:funlen
+package xerrors
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCollector_Wait(t *testing.T) {
+	t.Parallel()
+
+	xErr1 := NewXErr("test msg", "test descr", nil, nil)
+	xErr2 := NewXErr("test msg 2", "test descr 2", nil, nil)
+	xErr3 := NewXErr("test msg 3", "test descr 3", nil, nil)
+
+	tests := []struct {
+		name    string
+		collect func(c *Collector)
+		want    *XErrs
+	}{
+		{
+			name:    "no errors",
+			collect: func(c *Collector) {},
+			want:    nil,
+		},
+		{
+			name: "nil errors",
+			collect: func(c *Collector) {
+				c.Add(nil, (*XErr)(nil))
+				c.Go(func() XError { return nil })
+				c.Go(func() XError { return (*XErr)(nil) })
+			},
+			want: nil,
+		},
+		{
+			name: "errors in call order",
+			collect: func(c *Collector) {
+				c.Go(func() XError {
+					time.Sleep(10 * time.Millisecond)
+
+					return xErr1
+				})
+				c.Add(xErr2)
+				c.Go(func() XError { return xErr3 })
+			},
+			want: &XErrs{Errs: []XError{xErr1, xErr2, xErr3}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := NewCollector()
+			tt.collect(c)
+
+			if got := c.Wait(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wait() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollector_Add(t *testing.T) {
+	t.Parallel()
+
+	const n = 100
+
+	c := NewCollector()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			c.Add(New(strconv.Itoa(i)))
+		}(i)
+	}
+
+	wg.Wait()
+
+	if got := c.Wait().Len(); got != n {
+		t.Errorf("Wait().Len() = %v, want %v", got, n)
+	}
+}
+
+func TestCollector_Go(t *testing.T) {
+	t.Parallel()
+
+	const (
+		n     = 20
+		limit = 3
+	)
+
+	c := NewCollector(WithConcurrency(limit))
+
+	var running, maxRunning int32
+
+	for i := 0; i < n; i++ {
+		i := i
+
+		c.Go(func() XError {
+			cur := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+
+			for {
+				prev := atomic.LoadInt32(&maxRunning)
+				if cur <= prev || atomic.CompareAndSwapInt32(&maxRunning, prev, cur) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+
+			return New(strconv.Itoa(i))
+		})
+	}
+
+	xErrs := c.Wait()
+
+	if got := atomic.LoadInt32(&maxRunning); got > limit {
+		t.Errorf("max running goroutines = %v, want <= %v", got, limit)
+	}
+
+	if got := xErrs.Len(); got != n {
+		t.Fatalf("Wait().Len() = %v, want %v", got, n)
+	}
+
+	for i, xErr := range xErrs.GetErrors() {
+		if got := xErr.GetMessage(); got != strconv.Itoa(i) {
+			t.Errorf("Wait()[%d].GetMessage() = %v, want %v", i, got, i)
+		}
+	}
+}
+
+func TestCollector_FailFast(t *testing.T) {
+	t.Parallel()
+
+	c := NewCollector(WithConcurrency(1), WithFailFast())
+
+	var called int32
+
+	for i := 0; i < 10; i++ {
+		i := i
+
+		c.Go(func() XError {
+			atomic.AddInt32(&called, 1)
+
+			return New(strconv.Itoa(i))
+		})
+	}
+
+	xErrs := c.Wait()
+
+	if got := xErrs.Len(); got != 1 {
+		t.Errorf("Wait().Len() = %v, want 1", got)
+	}
+
+	if got := atomic.LoadInt32(&called); got != 1 {
+		t.Errorf("called = %v, want 1", got)
+	}
+}
@@ -0,0 +1,167 @@
+package xerrors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// StatusClientClosedRequest is non-standard HTTP status code used when client closed the request
+// before the server responded.
+const StatusClientClosedRequest = 499
+
+// Keys of request-scoped values added to XErr by WithContext.
+// Request ID is added to public extra, so users can refer to it, the rest is added to internal extra.
+const (
+	RequestIDKey  = "request_id"
+	TraceIDKey    = "trace_id"
+	SpanIDKey     = "span_id"
+	TraceStateKey = "tracestate"
+	UserIDKey     = "user_id"
+)
+
+// Internal extra keys of context deadline and time elapsed since ContextWithStart added by FromContext.
+const (
+	DeadlineKey = "deadline"
+	ElapsedKey  = "elapsed"
+)
+
+type (
+	startKey      struct{}
+	requestIDKey  struct{}
+	traceIDKey    struct{}
+	spanIDKey     struct{}
+	traceStateKey struct{}
+	userIDKey     struct{}
+)
+
+// ContextWithStart returns copy of ctx that holds operation start time,
+// FromContext uses it to report elapsed time.
+func ContextWithStart(ctx context.Context, start time.Time) context.Context {
+	return context.WithValue(ctx, startKey{}, start)
+}
+
+// StartFromContext returns operation start time stored by ContextWithStart.
+func StartFromContext(ctx context.Context) (time.Time, bool) {
+	start, ok := ctx.Value(startKey{}).(time.Time)
+
+	return start, ok
+}
+
+// ContextWithRequestID returns copy of ctx that holds request ID.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns request ID stored by ContextWithRequestID.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	return stringFromContext(ctx, requestIDKey{})
+}
+
+// ContextWithTraceID returns copy of ctx that holds trace ID.
+func ContextWithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, id)
+}
+
+// TraceIDFromContext returns trace ID stored by ContextWithTraceID.
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	return stringFromContext(ctx, traceIDKey{})
+}
+
+// ContextWithSpanID returns copy of ctx that holds span ID.
+func ContextWithSpanID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, spanIDKey{}, id)
+}
+
+// SpanIDFromContext returns span ID stored by ContextWithSpanID.
+func SpanIDFromContext(ctx context.Context) (string, bool) {
+	return stringFromContext(ctx, spanIDKey{})
+}
+
+// ContextWithTraceState returns copy of ctx that holds vendor-specific trace state, e.g. W3C tracestate header.
+func ContextWithTraceState(ctx context.Context, state string) context.Context {
+	return context.WithValue(ctx, traceStateKey{}, state)
+}
+
+// TraceStateFromContext returns trace state stored by ContextWithTraceState.
+func TraceStateFromContext(ctx context.Context) (string, bool) {
+	return stringFromContext(ctx, traceStateKey{})
+}
+
+// ContextWithUserID returns copy of ctx that holds ID of the user who made the request.
+func ContextWithUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, id)
+}
+
+// UserIDFromContext returns user ID stored by ContextWithUserID.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	return stringFromContext(ctx, userIDKey{})
+}
+
+func stringFromContext(ctx context.Context, key interface{}) (string, bool) {
+	v, ok := ctx.Value(key).(string)
+
+	return v, ok && v != ""
+}
+
+// enrich adds request-scoped values stored in ctx to err.
+func enrich(ctx context.Context, err *XErr) {
+	if id, ok := RequestIDFromContext(ctx); ok {
+		err.Extra = withValue(err.Extra, RequestIDKey, id)
+	}
+
+	for key, get := range map[string]func(context.Context) (string, bool){
+		TraceIDKey:    TraceIDFromContext,
+		SpanIDKey:     SpanIDFromContext,
+		TraceStateKey: TraceStateFromContext,
+		UserIDKey:     UserIDFromContext,
+	} {
+		if v, ok := get(ctx); ok {
+			err.InternalExtra = withValue(err.InternalExtra, key, v)
+		}
+	}
+}
+
+// FromContext maps err caused by context cancellation into XErr:
+// context.Canceled into ClientClosedRequest(499) and context.DeadlineExceeded into GatewayTimeout(504).
+// ctx deadline and time elapsed since ContextWithStart are added to internal extra along with err,
+// the XErr is created with WithContext(ctx). opts are applied after the defaults,
+// status code and internal extra are merged into extra maps set by opts.
+// It returns nil if err is not caused by context cancellation.
+func FromContext(ctx context.Context, err error, opts ...XErrOpt) *XErr {
+	var (
+		msg      string
+		defaults []XErrOpt
+		code     int
+	)
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		msg, code = "Client Closed Request", StatusClientClosedRequest
+		defaults = []XErrOpt{WithSeverity(SeverityInfo), WithKind(KindTransient)}
+	case errors.Is(err, context.DeadlineExceeded):
+		msg, code = http.StatusText(http.StatusGatewayTimeout), http.StatusGatewayTimeout
+		defaults = []XErrOpt{WithSeverity(SeverityError), WithKind(KindTransient), WithRetryable(true)}
+	default:
+		return nil
+	}
+
+	intExtra := map[string]interface{}{"error": err}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		intExtra[DeadlineKey] = deadline
+	}
+
+	if start, ok := StartFromContext(ctx); ok {
+		intExtra[ElapsedKey] = time.Since(start)
+	}
+
+	opts = append(append(defaults, opts...),
+		DefaultExtra(map[string]interface{}{"http_code": code}),
+		DefaultInternalExtra(intExtra),
+		WithContext(ctx),
+	)
+
+	return New(msg, opts...)
+}
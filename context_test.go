@@ -0,0 +1,180 @@
+// nolint:goerr113
+package xerrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	deadline := time.Now().Add(-time.Second)
+
+	deadlineCtx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	startCtx := ContextWithStart(deadlineCtx, deadline.Add(-time.Minute))
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		err           error
+		wantNil       bool
+		wantCode      int
+		wantRetryable bool
+		wantDeadline  bool
+		wantElapsed   bool
+	}{
+		{
+			name:    "nil error",
+			ctx:     context.Background(),
+			err:     nil,
+			wantNil: true,
+		},
+		{
+			name:    "not context error",
+			ctx:     context.Background(),
+			err:     errors.New("some error"),
+			wantNil: true,
+		},
+		{
+			name:          "canceled",
+			ctx:           context.Background(),
+			err:           context.Canceled,
+			wantCode:      StatusClientClosedRequest,
+			wantRetryable: false,
+		},
+		{
+			name:          "wrapped deadline exceeded",
+			ctx:           deadlineCtx,
+			err:           fmt.Errorf("query users: %w", context.DeadlineExceeded),
+			wantCode:      504,
+			wantRetryable: true,
+			wantDeadline:  true,
+		},
+		{
+			name:          "deadline exceeded with start",
+			ctx:           startCtx,
+			err:           startCtx.Err(),
+			wantCode:      504,
+			wantRetryable: true,
+			wantDeadline:  true,
+			wantElapsed:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := FromContext(tt.ctx, tt.err)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("FromContext() = %v, want nil", got)
+				}
+
+				return
+			}
+
+			if code := got.GetExtra()["http_code"]; code != tt.wantCode {
+				t.Errorf("FromContext() http_code = %v, want %v", code, tt.wantCode)
+			}
+
+			if got.Retryable() != tt.wantRetryable {
+				t.Errorf("FromContext() Retryable() = %v, want %v", got.Retryable(), tt.wantRetryable)
+			}
+
+			if !errors.Is(got, tt.err) {
+				t.Errorf("FromContext() cause = %v, want %v", got.Unwrap(), tt.err)
+			}
+
+			checkDeadlineExtra(t, got, tt.wantDeadline, tt.wantElapsed)
+		})
+	}
+}
+
+func checkDeadlineExtra(t *testing.T, got *XErr, wantDeadline, wantElapsed bool) {
+	t.Helper()
+
+	if _, ok := got.GetInternalExtra()[DeadlineKey]; ok != wantDeadline {
+		t.Errorf("FromContext() has deadline = %v, want %v", ok, wantDeadline)
+	}
+
+	elapsed, ok := got.GetInternalExtra()[ElapsedKey].(time.Duration)
+	if ok != wantElapsed || (ok && elapsed < time.Minute) {
+		t.Errorf("FromContext() elapsed = %v, want at least 1m: %v", elapsed, wantElapsed)
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := ContextWithRequestID(context.Background(), "req-1")
+	ctx = ContextWithTraceID(ctx, "trace-1")
+	ctx = ContextWithSpanID(ctx, "span-1")
+	ctx = ContextWithUserID(ctx, "user-1")
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		opts         []XErrOpt
+		wantExtra    map[string]interface{}
+		wantIntExtra map[string]interface{}
+	}{
+		{
+			name:         "empty context",
+			ctx:          context.Background(),
+			wantExtra:    nil,
+			wantIntExtra: nil,
+		},
+		{
+			name:         "empty values",
+			ctx:          ContextWithRequestID(context.Background(), ""),
+			wantExtra:    nil,
+			wantIntExtra: nil,
+		},
+		{
+			name:         "all values",
+			ctx:          ctx,
+			wantExtra:    map[string]interface{}{RequestIDKey: "req-1"},
+			wantIntExtra: map[string]interface{}{TraceIDKey: "trace-1", SpanIDKey: "span-1", UserIDKey: "user-1"},
+		},
+		{
+			name: "extra set after WithContext",
+			ctx:  ctx,
+			opts: []XErrOpt{
+				WithExtra(map[string]interface{}{"http_code": 404}),
+				WithInternalExtra(map[string]interface{}{"error": "some error"}),
+			},
+			wantExtra: map[string]interface{}{"http_code": 404, RequestIDKey: "req-1"},
+			wantIntExtra: map[string]interface{}{
+				"error": "some error", TraceIDKey: "trace-1", SpanIDKey: "span-1", UserIDKey: "user-1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			xErr := New("some error", append([]XErrOpt{WithContext(tt.ctx)}, tt.opts...)...)
+
+			if !reflect.DeepEqual(xErr.GetExtra(), tt.wantExtra) {
+				t.Errorf("GetExtra() = %v, want %v", xErr.GetExtra(), tt.wantExtra)
+			}
+
+			if !reflect.DeepEqual(xErr.GetInternalExtra(), tt.wantIntExtra) {
+				t.Errorf("GetInternalExtra() = %v, want %v", xErr.GetInternalExtra(), tt.wantIntExtra)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package xerrors
+
+import (
+	"encoding/json"
+	"log/slog"
+)
+
+// internalJSON is the representation of XErr with internal fields used by MarshalInternalJSON.
+type internalJSON struct {
+	Code          string                 `json:"code,omitempty"`
+	Message       string                 `json:"message"`
+	Description   string                 `json:"description,omitempty"`
+	Severity      string                 `json:"severity"`
+	Kind          Kind                   `json:"kind,omitempty"`
+	Retryable     bool                   `json:"retryable,omitempty"`
+	RetryAfter    string                 `json:"retry_after,omitempty"`
+	Extra         map[string]interface{} `json:"extra,omitempty"`
+	InternalExtra map[string]interface{} `json:"internal_extra,omitempty"`
+}
+
+// MarshalInternalJSON encodes XErr with internal fields, e.g. for logs and error reporting.
+// Unlike json.Marshal it includes severity, kind, retry hints and internal extra,
+// errors in internal extra are encoded as their messages.
+// It must not be used to encode errors sent to clients.
+func (err *XErr) MarshalInternalJSON() ([]byte, error) {
+	if err == nil {
+		return []byte("null"), nil
+	}
+
+	v := internalJSON{
+		Code:          err.Code,
+		Message:       err.Message,
+		Description:   err.Description,
+		Severity:      err.GetSeverity().String(),
+		Kind:          err.Kind,
+		Retryable:     err.retryable,
+		Extra:         err.Extra,
+		InternalExtra: encodableExtra(err.InternalExtra),
+	}
+
+	if err.retryAfter > 0 {
+		v.RetryAfter = err.retryAfter.String()
+	}
+
+	return json.Marshal(v)
+}
+
+// LogValue implements slog.LogValuer, the error is logged as a group with internal fields.
+func (err *XErr) LogValue() slog.Value {
+	if err == nil {
+		return slog.Value{}
+	}
+
+	attrs := make([]slog.Attr, 0, 9) // nolint:gomnd
+
+	attrs = append(attrs, slog.String("message", err.Message))
+
+	if err.Code != "" {
+		attrs = append(attrs, slog.String("code", err.Code))
+	}
+
+	if err.Description != "" {
+		attrs = append(attrs, slog.String("description", err.Description))
+	}
+
+	attrs = append(attrs, slog.String("severity", err.GetSeverity().String()))
+
+	if err.Kind != "" {
+		attrs = append(attrs, slog.String("kind", string(err.Kind)))
+	}
+
+	if err.retryable {
+		attrs = append(attrs, slog.Bool("retryable", true))
+	}
+
+	if err.retryAfter > 0 {
+		attrs = append(attrs, slog.Duration("retry_after", err.retryAfter))
+	}
+
+	if len(err.Extra) > 0 {
+		attrs = append(attrs, slog.Any("extra", err.Extra))
+	}
+
+	if len(err.InternalExtra) > 0 {
+		attrs = append(attrs, slog.Any("internal_extra", encodableExtra(err.InternalExtra)))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// encodableExtra returns a copy of extra with error values replaced by their messages,
+// as most errors have no exported fields and are encoded as empty objects.
+func encodableExtra(extra map[string]interface{}) map[string]interface{} {
+	if extra == nil {
+		return nil
+	}
+
+	encodable := make(map[string]interface{}, len(extra))
+
+	for k, v := range extra {
+		if err, ok := v.(error); ok && err != nil {
+			v = err.Error()
+		}
+
+		encodable[k] = v
+	}
+
+	return encodable
+}
@@ -0,0 +1,109 @@
+package xerrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestXErr_MarshalInternalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		xErr *XErr
+		want string
+	}{
+		{
+			name: "nil",
+			xErr: nil,
+			want: `null`,
+		},
+		{
+			name: "defaults",
+			xErr: New("test msg"),
+			want: `{"message":"test msg","severity":"error"}`,
+		},
+		{
+			name: "all fields",
+			xErr: New("test msg",
+				WithCode("test_code"),
+				WithDescription("test descr"),
+				WithSeverity(SeverityWarning),
+				WithKind(KindTransient),
+				WithRetryAfter(2*time.Second),
+				WithExtra(map[string]interface{}{"key": "value"}),
+				WithInternalExtra(map[string]interface{}{"error": errors.New("cause")}),
+			),
+			want: `{"code":"test_code","message":"test msg","description":"test descr","severity":"warning",` +
+				`"kind":"transient","retryable":true,"retry_after":"2s","extra":{"key":"value"},` +
+				`"internal_extra":{"error":"cause"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.xErr.MarshalInternalJSON()
+			if err != nil {
+				t.Fatalf("MarshalInternalJSON() error = %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("MarshalInternalJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXErr_LogValue(t *testing.T) {
+	t.Parallel()
+
+	xErr := New("test msg",
+		WithCode("test_code"),
+		WithKind(KindInternal),
+		WithExtra(map[string]interface{}{"key": "value"}),
+		WithInternalExtra(map[string]interface{}{"error": errors.New("cause")}),
+	)
+
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+	logger.Error("failed", "err", xErr)
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"msg": "failed",
+		"err": map[string]interface{}{
+			"message":        "test msg",
+			"code":           "test_code",
+			"severity":       "error",
+			"kind":           "internal",
+			"extra":          map[string]interface{}{"key": "value"},
+			"internal_extra": map[string]interface{}{"error": "cause"},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("logged %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,270 @@
+package xerrors
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Key is a typed key of a value stored in extra or internal extra.
+type Key[T any] struct {
+	name string
+}
+
+// NewKey returns new typed key with the given name.
+func NewKey[T any](name string) Key[T] {
+	return Key[T]{name: name}
+}
+
+// Name returns name of the key used in extra maps.
+func (k Key[T]) Name() string { return k.name }
+
+// Extra returns value of xErr extra stored under the key, see ExtraValue.
+func (k Key[T]) Extra(xErr XError) (T, bool) { return ExtraValue[T](xErr, k.name) }
+
+// InternalExtra returns value of xErr internal extra stored under the key, see InternalExtraValue.
+func (k Key[T]) InternalExtra(xErr XError) (T, bool) { return InternalExtraValue[T](xErr, k.name) }
+
+// WithExtraKey sets extra value under the typed key, other extra values are kept.
+func WithExtraKey[T any](key Key[T], v T) XErrOpt { return WithExtraField(key.name, v) }
+
+// WithInternalExtraKey sets internal extra value under the typed key, other internal extra values are kept.
+func WithInternalExtraKey[T any](key Key[T], v T) XErrOpt { return WithInternalExtraField(key.name, v) }
+
+// Unlike WithExtra and WithInternalExtra, which replace the whole map, the following options merge values
+// into the map set by preceding options. Merge options overwrite existing keys, so the last option wins,
+// Default options set only missing keys, so the first option wins. Maps passed to options are never modified.
+
+// WithExtraField sets extra value under key, other extra values are kept.
+func WithExtraField(key string, v interface{}) XErrOpt {
+	return func(err *XErr) { err.Extra = withValue(err.Extra, key, v) }
+}
+
+// WithInternalExtraField sets internal extra value under key, other internal extra values are kept.
+func WithInternalExtraField(key string, v interface{}) XErrOpt {
+	return func(err *XErr) { err.InternalExtra = withValue(err.InternalExtra, key, v) }
+}
+
+// MergeExtra merges m into extra, values from m overwrite existing ones.
+func MergeExtra(m map[string]interface{}) XErrOpt {
+	return func(err *XErr) { err.Extra = merge(err.Extra, m, true) }
+}
+
+// MergeInternalExtra merges m into internal extra, values from m overwrite existing ones.
+func MergeInternalExtra(m map[string]interface{}) XErrOpt {
+	return func(err *XErr) { err.InternalExtra = merge(err.InternalExtra, m, true) }
+}
+
+// DefaultExtra merges m into extra, existing values are kept.
+func DefaultExtra(m map[string]interface{}) XErrOpt {
+	return func(err *XErr) { err.Extra = merge(err.Extra, m, false) }
+}
+
+// DefaultInternalExtra merges m into internal extra, existing values are kept.
+func DefaultInternalExtra(m map[string]interface{}) XErrOpt {
+	return func(err *XErr) { err.InternalExtra = merge(err.InternalExtra, m, false) }
+}
+
+// ExtraValue returns value of xErr extra stored under key converted to T,
+// false is returned if there is no such value or it can't be converted.
+// Numbers are converted between numeric types only if the value is represented exactly,
+// e.g. integers above 2^53 can't be read as float64, so values decoded from JSON as float64
+// or json.Number can be read as int.
+func ExtraValue[T any](xErr XError, key string) (T, bool) {
+	if xErr == nil {
+		var zero T
+
+		return zero, false
+	}
+
+	return convert[T](xErr.GetExtra()[key])
+}
+
+// InternalExtraValue returns value of xErr internal extra stored under key converted to T, see ExtraValue.
+func InternalExtraValue[T any](xErr XError, key string) (T, bool) {
+	if xErr == nil {
+		var zero T
+
+		return zero, false
+	}
+
+	return convert[T](xErr.GetInternalExtra()[key])
+}
+
+// merge returns copy of dst with values from src, existing keys are replaced only if overwrite is true.
+func merge(dst, src map[string]interface{}, overwrite bool) map[string]interface{} {
+	if len(src) == 0 {
+		return dst
+	}
+
+	merged := make(map[string]interface{}, len(dst)+len(src))
+	for k, v := range dst {
+		merged[k] = v
+	}
+
+	for k, v := range src {
+		if _, ok := merged[k]; ok && !overwrite {
+			continue
+		}
+
+		merged[k] = v
+	}
+
+	return merged
+}
+
+func convert[T any](v interface{}) (T, bool) {
+	var zero T
+
+	if t, ok := v.(T); ok {
+		return t, true
+	}
+
+	if v == nil {
+		return zero, false
+	}
+
+	if !setNumber(reflect.ValueOf(&zero).Elem(), v) {
+		var unset T
+
+		return unset, false
+	}
+
+	return zero, true
+}
+
+// setNumber sets numeric v to numeric dst if v is represented exactly by dst type.
+func setNumber(dst reflect.Value, v interface{}) bool {
+	switch dst.Kind() { // nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return setInt(dst, v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return setUint(dst, v)
+	case reflect.Float32, reflect.Float64:
+		return setFloat(dst, v)
+	default:
+		return false
+	}
+}
+
+func setInt(dst reflect.Value, v interface{}) bool {
+	n, ok := toInt64(v)
+	if !ok || dst.OverflowInt(n) {
+		return false
+	}
+
+	dst.SetInt(n)
+
+	return true
+}
+
+func setUint(dst reflect.Value, v interface{}) bool {
+	n, ok := toUint64(v)
+	if !ok || dst.OverflowUint(n) {
+		return false
+	}
+
+	dst.SetUint(n)
+
+	return true
+}
+
+func setFloat(dst reflect.Value, v interface{}) bool {
+	f, ok := toFloat64(v)
+	if !ok || dst.OverflowFloat(f) || (dst.Kind() == reflect.Float32 && float64(float32(f)) != f) {
+		return false
+	}
+
+	dst.SetFloat(f)
+
+	return true
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	if n, ok := v.(json.Number); ok {
+		i, err := n.Int64()
+
+		return i, err == nil
+	}
+
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() { // nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := rv.Uint()
+
+		return int64(u), u <= math.MaxInt64
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, false
+		}
+
+		return int64(f), true
+	default:
+		return 0, false
+	}
+}
+
+func toUint64(v interface{}) (uint64, bool) {
+	if n, ok := v.(json.Number); ok {
+		u, err := strconv.ParseUint(n.String(), 10, 64)
+
+		return u, err == nil
+	}
+
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() { // nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := rv.Int()
+
+		return uint64(i), i >= 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+			return 0, false
+		}
+
+		return uint64(f), true
+	default:
+		return 0, false
+	}
+}
+
+// toFloat64 converts numeric v into float64, false is returned if v can't be represented exactly.
+func toFloat64(v interface{}) (float64, bool) {
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return toFloat64(i)
+		}
+
+		f, err := n.Float64()
+
+		return f, err == nil
+	}
+
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() { // nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := rv.Int()
+		f := float64(i)
+
+		return f, f < math.MaxInt64 && int64(f) == i
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := rv.Uint()
+		f := float64(u)
+
+		return f, f < math.MaxUint64 && uint64(f) == u
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,297 @@
+// nolint:funlen
+package xerrors
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExtraValue(t *testing.T) {
+	t.Parallel()
+
+	var decoded XErr
+	data := []byte(`{"message":"test msg","extra":{"id":42,"ratio":0.5,"name":"test"}}`)
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	xErr := New("test msg", WithExtra(map[string]interface{}{
+		"int":      7,
+		"negative": -1,
+		"number":   json.Number("12"),
+		"big":      int64(1) << 40,
+		"2^53":     int64(1) << 53,
+		"2^53+1":   int64(1)<<53 + 1,
+		"tenth":    0.1,
+		"long":     json.Number("9007199254740993"),
+		"2^63":     uint64(1) << 63,
+		"maxuint":  json.Number("18446744073709551615"),
+	}))
+
+	tests := []struct {
+		name   string
+		get    func() (interface{}, bool)
+		want   interface{}
+		wantOk bool
+	}{
+		{
+			name:   "nil error",
+			get:    func() (interface{}, bool) { return ExtraValue[int](nil, "id") },
+			want:   0,
+			wantOk: false,
+		},
+		{
+			name:   "nil XErr",
+			get:    func() (interface{}, bool) { return ExtraValue[int]((*XErr)(nil), "id") },
+			want:   0,
+			wantOk: false,
+		},
+		{
+			name:   "missing key",
+			get:    func() (interface{}, bool) { return ExtraValue[string](xErr, "missing") },
+			want:   "",
+			wantOk: false,
+		},
+		{
+			name:   "exact type",
+			get:    func() (interface{}, bool) { return ExtraValue[int](xErr, "int") },
+			want:   7,
+			wantOk: true,
+		},
+		{
+			name:   "type mismatch",
+			get:    func() (interface{}, bool) { return ExtraValue[string](xErr, "int") },
+			want:   "",
+			wantOk: false,
+		},
+		{
+			name:   "JSON number as int",
+			get:    func() (interface{}, bool) { return ExtraValue[int](&decoded, "id") },
+			want:   42,
+			wantOk: true,
+		},
+		{
+			name:   "JSON fraction as int",
+			get:    func() (interface{}, bool) { return ExtraValue[int](&decoded, "ratio") },
+			want:   0,
+			wantOk: false,
+		},
+		{
+			name:   "JSON fraction as float32",
+			get:    func() (interface{}, bool) { return ExtraValue[float32](&decoded, "ratio") },
+			want:   float32(0.5),
+			wantOk: true,
+		},
+		{
+			name:   "JSON string",
+			get:    func() (interface{}, bool) { return ExtraValue[string](&decoded, "name") },
+			want:   "test",
+			wantOk: true,
+		},
+		{
+			name:   "json.Number as int64",
+			get:    func() (interface{}, bool) { return ExtraValue[int64](xErr, "number") },
+			want:   int64(12),
+			wantOk: true,
+		},
+		{
+			name:   "int as float64",
+			get:    func() (interface{}, bool) { return ExtraValue[float64](xErr, "int") },
+			want:   float64(7),
+			wantOk: true,
+		},
+		{
+			name:   "exact int64 as float64",
+			get:    func() (interface{}, bool) { return ExtraValue[float64](xErr, "2^53") },
+			want:   float64(1 << 53),
+			wantOk: true,
+		},
+		{
+			name:   "inexact int64 as float64",
+			get:    func() (interface{}, bool) { return ExtraValue[float64](xErr, "2^53+1") },
+			want:   float64(0),
+			wantOk: false,
+		},
+		{
+			name:   "inexact json.Number as float64",
+			get:    func() (interface{}, bool) { return ExtraValue[float64](xErr, "long") },
+			want:   float64(0),
+			wantOk: false,
+		},
+		{
+			name:   "inexact float64 as float32",
+			get:    func() (interface{}, bool) { return ExtraValue[float32](xErr, "tenth") },
+			want:   float32(0),
+			wantOk: false,
+		},
+		{
+			name:   "negative as uint",
+			get:    func() (interface{}, bool) { return ExtraValue[uint](xErr, "negative") },
+			want:   uint(0),
+			wantOk: false,
+		},
+		{
+			name:   "uint64 above max int64 as uint",
+			get:    func() (interface{}, bool) { return ExtraValue[uint](xErr, "2^63") },
+			want:   uint(1) << 63,
+			wantOk: true,
+		},
+		{
+			name:   "max uint64 json.Number as uint64",
+			get:    func() (interface{}, bool) { return ExtraValue[uint64](xErr, "maxuint") },
+			want:   uint64(math.MaxUint64),
+			wantOk: true,
+		},
+		{
+			name:   "uint64 above max int64 as int64",
+			get:    func() (interface{}, bool) { return ExtraValue[int64](xErr, "2^63") },
+			want:   int64(0),
+			wantOk: false,
+		},
+		{
+			name:   "uint overflow",
+			get:    func() (interface{}, bool) { return ExtraValue[uint32](xErr, "2^63") },
+			want:   uint32(0),
+			wantOk: false,
+		},
+		{
+			name:   "overflow",
+			get:    func() (interface{}, bool) { return ExtraValue[int32](xErr, "big") },
+			want:   int32(0),
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := tt.get()
+			if !reflect.DeepEqual(got, tt.want) || ok != tt.wantOk {
+				t.Errorf("ExtraValue() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	var (
+		userID  = NewKey[int]("user_id")
+		timeout = NewKey[time.Duration]("timeout")
+	)
+
+	extra := map[string]interface{}{"key": "value"}
+
+	xErr := New("test msg",
+		WithExtra(extra),
+		WithExtraKey(userID, 42),
+		WithInternalExtraKey(timeout, time.Second),
+	)
+
+	if got, ok := userID.Extra(xErr); got != 42 || !ok {
+		t.Errorf("Extra() = %v, %v, want 42, true", got, ok)
+	}
+
+	if got, ok := timeout.InternalExtra(xErr); got != time.Second || !ok {
+		t.Errorf("InternalExtra() = %v, %v, want %v, true", got, ok, time.Second)
+	}
+
+	if _, ok := userID.InternalExtra(xErr); ok {
+		t.Errorf("InternalExtra() found value stored in extra")
+	}
+
+	if got := xErr.GetExtra()["key"]; got != "value" {
+		t.Errorf("GetExtra()[key] = %v, want value", got)
+	}
+
+	if len(extra) != 1 {
+		t.Errorf("caller's extra was modified: %v", extra)
+	}
+
+	if got := userID.Name(); got != "user_id" {
+		t.Errorf("Name() = %v, want user_id", got)
+	}
+}
+
+func TestMergeOptions(t *testing.T) {
+	t.Parallel()
+
+	base := map[string]interface{}{"a": 1, "b": 2}
+
+	tests := []struct {
+		name              string
+		opts              []XErrOpt
+		wantExtra         map[string]interface{}
+		wantInternalExtra map[string]interface{}
+	}{
+		{
+			name:      "field added to nil map",
+			opts:      []XErrOpt{WithExtraField("a", 1)},
+			wantExtra: map[string]interface{}{"a": 1},
+		},
+		{
+			name:      "field added to existing map",
+			opts:      []XErrOpt{WithExtra(base), WithExtraField("c", 3)},
+			wantExtra: map[string]interface{}{"a": 1, "b": 2, "c": 3},
+		},
+		{
+			name:      "field overwrites existing value",
+			opts:      []XErrOpt{WithExtra(base), WithExtraField("a", 10)},
+			wantExtra: map[string]interface{}{"a": 10, "b": 2},
+		},
+		{
+			name:      "merge overwrites existing values",
+			opts:      []XErrOpt{WithExtra(base), MergeExtra(map[string]interface{}{"b": 20, "c": 3})},
+			wantExtra: map[string]interface{}{"a": 1, "b": 20, "c": 3},
+		},
+		{
+			name:      "default keeps existing values",
+			opts:      []XErrOpt{WithExtra(base), DefaultExtra(map[string]interface{}{"b": 20, "c": 3})},
+			wantExtra: map[string]interface{}{"a": 1, "b": 2, "c": 3},
+		},
+		{
+			name:      "replace after merge",
+			opts:      []XErrOpt{MergeExtra(base), WithExtra(map[string]interface{}{"c": 3})},
+			wantExtra: map[string]interface{}{"c": 3},
+		},
+		{
+			name: "internal extra",
+			opts: []XErrOpt{
+				WithInternalExtra(base),
+				WithInternalExtraField("c", 3),
+				MergeInternalExtra(map[string]interface{}{"a": 10}),
+				DefaultInternalExtra(map[string]interface{}{"b": 20, "d": 4}),
+			},
+			wantInternalExtra: map[string]interface{}{"a": 10, "b": 2, "c": 3, "d": 4},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			xErr := New("test msg", tt.opts...)
+
+			if !reflect.DeepEqual(xErr.Extra, tt.wantExtra) {
+				t.Errorf("Extra = %v, want %v", xErr.Extra, tt.wantExtra)
+			}
+
+			if !reflect.DeepEqual(xErr.InternalExtra, tt.wantInternalExtra) {
+				t.Errorf("InternalExtra = %v, want %v", xErr.InternalExtra, tt.wantInternalExtra)
+			}
+
+			if want := map[string]interface{}{"a": 1, "b": 2}; !reflect.DeepEqual(base, want) {
+				t.Errorf("caller's map was modified: %v", base)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package xerrors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+const (
+	// FingerprintKey is the internal extra key of the fingerprint set by WithFingerprint.
+	FingerprintKey = "fingerprint"
+
+	modulePath        = "github.com/eugeneradionov/xerrors"
+	fingerprintFrames = 3
+	maxStackDepth     = 32
+)
+
+// nolint:gochecknoglobals
+var (
+	uuidRe   = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	numberRe = regexp.MustCompile(`\d+`)
+)
+
+// WithStack captures the stack trace of the XErr creation, it's used by Fingerprint.
+func WithStack() XErrOpt {
+	return func(err *XErr) {
+		pcs := make([]uintptr, maxStackDepth)
+		err.stack = pcs[:runtime.Callers(1, pcs)]
+	}
+}
+
+// WithFingerprint adds Fingerprint of the XErr to internal extra under FingerprintKey.
+// The fingerprint is computed after all other options are applied.
+func WithFingerprint() XErrOpt {
+	return func(err *XErr) {
+		err.deferred = append(err.deferred, func(err *XErr) {
+			err.InternalExtra = withValue(err.InternalExtra, FingerprintKey, Fingerprint(err))
+		})
+	}
+}
+
+// StackTrace returns stack trace captured by WithStack without frames of xerrors packages.
+func (err *XErr) StackTrace() []runtime.Frame {
+	if err == nil || len(err.stack) == 0 {
+		return nil
+	}
+
+	var trace []runtime.Frame
+
+	frames := runtime.CallersFrames(err.stack)
+
+	for {
+		frame, more := frames.Next()
+		if !isOwnFrame(frame) {
+			trace = append(trace, frame)
+		}
+
+		if !more {
+			return trace
+		}
+	}
+}
+
+// Fingerprint returns stable hash that identifies the same logical error for grouping occurrences.
+// It's computed from the code, the message with numbers and UUIDs masked and the top frames of the stack trace
+// captured by WithStack. Description and extra are ignored, as they usually contain variable data.
+func Fingerprint(xErr XError) string {
+	if IsNil(xErr) {
+		return ""
+	}
+
+	h := sha256.New()
+	h.Write([]byte(CodeKey(xErr)))
+	h.Write([]byte{0})
+	h.Write([]byte(MessageTemplate(xErr.GetMessage())))
+
+	if st, ok := xErr.(interface{ StackTrace() []runtime.Frame }); ok {
+		for i, frame := range st.StackTrace() {
+			if i == fingerprintFrames {
+				break
+			}
+
+			h.Write([]byte{0})
+			h.Write([]byte(frame.Function))
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// MessageTemplate masks variable data, such as numeric IDs and UUIDs, in msg.
+func MessageTemplate(msg string) string {
+	msg = uuidRe.ReplaceAllString(msg, "<uuid>")
+
+	return numberRe.ReplaceAllString(msg, "<n>")
+}
+
+// isOwnFrame reports whether frame belongs to xerrors packages, except tests.
+func isOwnFrame(frame runtime.Frame) bool {
+	own := strings.HasPrefix(frame.Function, modulePath+".") || strings.HasPrefix(frame.Function, modulePath+"/")
+
+	return own && !strings.HasSuffix(frame.File, "_test.go")
+}
+
+// withValue returns copy of m with key set to v, so maps passed in options are never modified.
+func withValue(m map[string]interface{}, key string, v interface{}) map[string]interface{} {
+	cp := make(map[string]interface{}, len(m)+1)
+	for k, val := range m {
+		cp[k] = val
+	}
+
+	cp[key] = v
+
+	return cp
+}
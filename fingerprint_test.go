@@ -0,0 +1,106 @@
+package xerrors
+
+import (
+	"strings"
+	"testing"
+)
+
+func newUserNotFound(id int) *XErr {
+	return New("user "+strings.Repeat("1", id)+" not found", WithCode("user_not_found"), WithStack())
+}
+
+func newUserNotFoundAgain(id int) *XErr {
+	return New("user "+strings.Repeat("1", id)+" not found", WithCode("user_not_found"), WithStack())
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a    XError
+		b    XError
+		same bool
+	}{
+		{
+			name: "different code",
+			a:    New("not found", WithCode("not_found"), WithDescription("user 1")),
+			b:    NewXErr("not found", "user 2", map[string]interface{}{"id": 2}, nil),
+			same: false,
+		},
+		{
+			name: "same code, different description and extra",
+			a:    New("not found", WithCode("not_found"), WithDescription("user 1")),
+			b:    New("not found", WithCode("not_found"), WithExtra(map[string]interface{}{"id": 2})),
+			same: true,
+		},
+		{
+			name: "numbers and UUIDs in message",
+			a:    New("order 42 of 0b3c6f3e-9b9a-4a53-8f0e-0c5c1b7f0a11 failed"),
+			b:    New("order 7 of 9e1d7c7a-1f2b-4c3d-9e8f-7a6b5c4d3e2f failed"),
+			same: true,
+		},
+		{
+			name: "same call site",
+			a:    newUserNotFound(1),
+			b:    newUserNotFound(2),
+			same: true,
+		},
+		{
+			name: "different call sites",
+			a:    newUserNotFound(1),
+			b:    newUserNotFoundAgain(1),
+			same: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			a, b := Fingerprint(tt.a), Fingerprint(tt.b)
+			if (a == b) != tt.same {
+				t.Errorf("Fingerprint() = %v and %v, want same: %v", a, b, tt.same)
+			}
+		})
+	}
+
+	if got := Fingerprint(nil); got != "" {
+		t.Errorf("Fingerprint(nil) = %v, want empty", got)
+	}
+}
+
+func TestWithFingerprint(t *testing.T) {
+	t.Parallel()
+
+	intExtra := map[string]interface{}{"error_info": "connect to db"}
+	xErr := New("some error", WithFingerprint(), WithCode("db_error"), WithInternalExtra(intExtra))
+
+	want := Fingerprint(New("other message", WithCode("db_error"), WithMessage("some error")))
+	if got := xErr.GetInternalExtra()[FingerprintKey]; got != want {
+		t.Errorf("WithFingerprint() fingerprint = %v, want %v", got, want)
+	}
+
+	if got := xErr.GetInternalExtra()["error_info"]; got != "connect to db" {
+		t.Errorf("WithFingerprint() error_info = %v, want connect to db", got)
+	}
+
+	if _, ok := intExtra[FingerprintKey]; ok {
+		t.Errorf("WithFingerprint() modified internal extra passed in options")
+	}
+}
+
+func TestXErr_StackTrace(t *testing.T) {
+	t.Parallel()
+
+	if got := New("some error").StackTrace(); got != nil {
+		t.Errorf("StackTrace() = %v, want nil", got)
+	}
+
+	trace := newUserNotFound(1).StackTrace()
+	if len(trace) == 0 || !strings.HasSuffix(trace[0].Function, ".newUserNotFound") {
+		t.Errorf("StackTrace() top frame = %v, want newUserNotFound", trace)
+	}
+}
@@ -0,0 +1,77 @@
+package xerrors
+
+import (
+	"encoding/json"
+	"sync/atomic"
+)
+
+// Freeze makes err immutable, so it can be shared between goroutines, e.g. as a package-level error,
+// and returns it. Extra maps are copied, so maps passed in options can't modify it.
+// Sanitize of frozen XErr can't remove sensitive information from it, use Sanitized to get sanitized copy.
+// It panics in debug builds (xerrorsdebug build tag) to find the misuse. Otherwise it fails safe:
+// err is not modified and sanitize hooks are not run, but description is hidden from GetDescription,
+// Error and MarshalJSON for all holders of err, MarshalInternalJSON and LogValue still include it.
+// Getters of extra maps return copies.
+// Freeze must be called before err is shared, exported fields must not be modified after it.
+func (err *XErr) Freeze() *XErr {
+	if err == nil || err.frozen {
+		return err
+	}
+
+	err.Extra = merge(nil, err.Extra, true)
+	err.InternalExtra = merge(nil, err.InternalExtra, true)
+	err.concealed = new(atomic.Bool)
+	err.frozen = true
+
+	return err
+}
+
+// IsFrozen reports whether err is frozen with Freeze.
+func (err *XErr) IsFrozen() bool {
+	return err != nil && err.frozen
+}
+
+// Sanitized returns sanitized copy of err, err itself is not modified.
+// The copy is frozen if err is frozen.
+func (err *XErr) Sanitized() *XErr {
+	if err == nil {
+		return nil
+	}
+
+	cp := err.Clone()
+	cp.frozen = false
+
+	cp.Sanitize()
+
+	cp.frozen = err.frozen
+
+	return cp
+}
+
+// conceal hides description of frozen err from public views, it panics in debug builds.
+func (err *XErr) conceal() {
+	if debug {
+		panic("xerrors: Sanitize called on frozen XErr, use Sanitized instead")
+	}
+
+	err.concealed.Store(true)
+}
+
+// isConcealed reports whether Sanitize was called on frozen err.
+func (err *XErr) isConcealed() bool {
+	return err.concealed != nil && err.concealed.Load()
+}
+
+// MarshalJSON encodes public fields of XErr, description of concealed frozen XErr is omitted.
+func (err *XErr) MarshalJSON() ([]byte, error) {
+	type public XErr // public has no MarshalJSON method
+
+	if err == nil {
+		return []byte("null"), nil
+	}
+
+	v := *(*public)(err)
+	v.Description = err.GetDescription()
+
+	return json.Marshal(v)
+}
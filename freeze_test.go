@@ -0,0 +1,162 @@
+// nolint:funlen
+package xerrors
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestXErr_Freeze(t *testing.T) {
+	t.Parallel()
+
+	extra := map[string]interface{}{"key": "value"}
+	xErr := New("test msg", WithDescription("test descr"), WithExtra(extra)).Freeze()
+
+	if !xErr.IsFrozen() {
+		t.Fatalf("IsFrozen() = false, want true")
+	}
+
+	extra["key"] = "modified"
+
+	got := xErr.GetExtra()
+	if !reflect.DeepEqual(got, map[string]interface{}{"key": "value"}) {
+		t.Errorf("GetExtra() = %v after modifying option map", got)
+	}
+
+	got["key"] = "modified"
+
+	if got := xErr.GetExtra()["key"]; got != "value" {
+		t.Errorf("GetExtra()[key] = %v after modifying returned map, want value", got)
+	}
+
+	sanitized := xErr.Sanitized()
+
+	if got := sanitized.GetDescription(); got != "" {
+		t.Errorf("Sanitized().GetDescription() = %v, want empty", got)
+	}
+
+	if !sanitized.IsFrozen() {
+		t.Errorf("Sanitized().IsFrozen() = false, want true")
+	}
+
+	if got := xErr.GetDescription(); got != "test descr" {
+		t.Errorf("GetDescription() = %v after Sanitized, want test descr", got)
+	}
+
+	if (*XErr)(nil).Freeze() != nil || (*XErr)(nil).IsFrozen() || (*XErr)(nil).Sanitized() != nil {
+		t.Errorf("nil XErr methods returned not nil values")
+	}
+}
+
+func TestXErr_Sanitize_Frozen(t *testing.T) {
+	t.Parallel()
+
+	xErr := New("test msg", WithDescription("test descr")).Freeze()
+
+	defer func() {
+		if r := recover(); (r != nil) != debug {
+			t.Errorf("Sanitize() panic = %v, want panic %v", r, debug)
+		}
+
+		if xErr.Description != "test descr" {
+			t.Errorf("Description = %v after Sanitize, want test descr", xErr.Description)
+		}
+
+		if debug {
+			return
+		}
+
+		if got := xErr.GetDescription(); got != "" {
+			t.Errorf("GetDescription() = %v after Sanitize, want empty", got)
+		}
+
+		if got := xErr.Error(); got != "test msg: ; map[]" {
+			t.Errorf("Error() = %v after Sanitize, want test msg: ; map[]", got)
+		}
+
+		if got, _ := json.Marshal(xErr); string(got) != `{"message":"test msg"}` {
+			t.Errorf("json.Marshal() = %s after Sanitize, want {\"message\":\"test msg\"}", got)
+		}
+
+		if got, _ := xErr.MarshalInternalJSON(); !strings.Contains(string(got), "test descr") {
+			t.Errorf("MarshalInternalJSON() = %s after Sanitize, want description", got)
+		}
+	}()
+
+	xErr.Sanitize()
+}
+
+func TestXErrs_Sanitize_Frozen(t *testing.T) {
+	t.Parallel()
+
+	frozen := New("frozen", WithDescription("test descr")).Freeze()
+	mutable := New("mutable", WithDescription("test descr"))
+
+	xErrs := NewXErrs()
+	xErrs.Add(frozen, mutable)
+	xErrs.Sanitize()
+
+	for i, xErr := range xErrs.GetErrors() {
+		if got := xErr.GetDescription(); got != "" {
+			t.Errorf("GetErrors()[%d].GetDescription() = %v, want empty", i, got)
+		}
+	}
+
+	if xErrs.GetErrors()[0] == XError(frozen) {
+		t.Errorf("frozen error was not replaced with a copy")
+	}
+
+	if got := frozen.GetDescription(); got != "test descr" {
+		t.Errorf("frozen GetDescription() = %v, want test descr", got)
+	}
+}
+
+// TestXErr_Freeze_Concurrent must be run with -race.
+func TestXErr_Freeze_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	shared := New("test msg",
+		WithDescription("test descr"),
+		WithExtra(map[string]interface{}{"key": "value"}),
+	).Freeze()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if !debug {
+				shared.Sanitize()
+			}
+
+			xErrs := NewXErrs()
+			xErrs.Add(shared)
+			xErrs.Sanitize()
+
+			extra := shared.GetExtra()
+			extra["goroutine"] = true
+
+			_ = shared.Error()
+
+			if got := shared.Sanitized().GetDescription(); got != "" {
+				t.Errorf("Sanitized().GetDescription() = %v, want empty", got)
+			}
+
+			if got := xErrs.First().GetDescription(); got != "" {
+				t.Errorf("XErrs.Sanitize() left description %v", got)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if shared.Description != "test descr" {
+		t.Errorf("Description = %v, want test descr", shared.Description)
+	}
+}
@@ -0,0 +1,148 @@
+package xerrors
+
+import (
+	"context"
+	"sync"
+)
+
+// Hooks is a registry of observers invoked when XErr is created or sanitized.
+// Observers are invoked in the order they were registered.
+type Hooks struct {
+	mu         sync.RWMutex
+	lastID     uint64
+	onNew      []hook
+	onSanitize []hook
+}
+
+type hook struct {
+	id uint64
+	fn func(*XErr)
+}
+
+var globalHooks = NewHooks() // nolint:gochecknoglobals
+
+// NewHooks returns new empty instance of Hooks, use ContextWithHooks to scope them to a context.
+func NewHooks() *Hooks {
+	return &Hooks{}
+}
+
+// OnNew registers fn that is invoked by New after all options are applied.
+// The returned func unregisters fn.
+func (h *Hooks) OnNew(fn func(*XErr)) (unregister func()) {
+	return h.register(&h.onNew, fn)
+}
+
+// OnSanitize registers fn that is invoked by XErr.Sanitize after sensitive information is removed.
+// The returned func unregisters fn.
+func (h *Hooks) OnSanitize(fn func(*XErr)) (unregister func()) {
+	return h.register(&h.onSanitize, fn)
+}
+
+// OnNew registers global fn that is invoked by New for every XErr, see Hooks.OnNew.
+// The returned func unregisters fn, e.g. in tests.
+func OnNew(fn func(*XErr)) (unregister func()) {
+	return globalHooks.OnNew(fn)
+}
+
+// OnSanitize registers global fn that is invoked by XErr.Sanitize, see Hooks.OnSanitize.
+// The returned func unregisters fn, e.g. in tests.
+func OnSanitize(fn func(*XErr)) (unregister func()) {
+	return globalHooks.OnSanitize(fn)
+}
+
+func (h *Hooks) register(hooks *[]hook, fn func(*XErr)) func() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastID++
+	id := h.lastID
+
+	*hooks = append(*hooks, hook{id: id, fn: fn})
+
+	var once sync.Once
+
+	return func() { once.Do(func() { h.unregister(hooks, id) }) }
+}
+
+// unregister removes hook with id, hooks are copied as running hooks may still iterate over the old slice.
+func (h *Hooks) unregister(hooks *[]hook, id uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	kept := make([]hook, 0, len(*hooks))
+
+	for _, hk := range *hooks {
+		if hk.id != id {
+			kept = append(kept, hk)
+		}
+	}
+
+	*hooks = kept
+}
+
+type hooksKey struct{}
+
+// ContextWithHooks returns copy of ctx that holds hooks in addition to hooks of the parent contexts.
+// Hooks stored in the context are invoked for errors created with WithContext option.
+func ContextWithHooks(ctx context.Context, hooks *Hooks) context.Context {
+	parent := hooksFromContext(ctx)
+
+	scoped := make([]*Hooks, len(parent), len(parent)+1)
+	copy(scoped, parent)
+
+	return context.WithValue(ctx, hooksKey{}, append(scoped, hooks))
+}
+
+// WithContext scopes XErr to ctx: request ID stored in ctx is added to extra,
+// trace ID, span ID, trace state and user ID are added to internal extra after all other options are applied.
+// Hooks stored by ContextWithHooks are invoked after global hooks, from the outermost context to the innermost.
+func WithContext(ctx context.Context) XErrOpt {
+	return func(err *XErr) {
+		err.scoped = hooksFromContext(ctx)
+		err.deferred = append(err.deferred, func(err *XErr) { enrich(ctx, err) })
+	}
+}
+
+func hooksFromContext(ctx context.Context) []*Hooks {
+	hooks, _ := ctx.Value(hooksKey{}).([]*Hooks)
+
+	return hooks
+}
+
+func (h *Hooks) newHooks() []hook {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.onNew
+}
+
+func (h *Hooks) sanitizeHooks() []hook {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.onSanitize
+}
+
+func runNewHooks(err *XErr) {
+	for _, hk := range globalHooks.newHooks() {
+		hk.fn(err)
+	}
+
+	for _, hooks := range err.scoped {
+		for _, hk := range hooks.newHooks() {
+			hk.fn(err)
+		}
+	}
+}
+
+func runSanitizeHooks(err *XErr) {
+	for _, hk := range globalHooks.sanitizeHooks() {
+		hk.fn(err)
+	}
+
+	for _, hooks := range err.scoped {
+		for _, hk := range hooks.sanitizeHooks() {
+			hk.fn(err)
+		}
+	}
+}
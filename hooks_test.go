@@ -0,0 +1,138 @@
+package xerrors
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type hookRecorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *hookRecorder) hook(name, code string) func(*XErr) {
+	return func(err *XErr) {
+		if err.Code != code {
+			return
+		}
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.calls = append(r.calls, name)
+	}
+}
+
+func (r *hookRecorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.calls...)
+}
+
+func TestHooks(t *testing.T) {
+	t.Parallel()
+
+	const code = "hooks_test"
+
+	rec := &hookRecorder{}
+
+	t.Cleanup(OnNew(rec.hook("global new 1", code)))
+	t.Cleanup(OnNew(rec.hook("global new 2", code)))
+	t.Cleanup(OnSanitize(rec.hook("global sanitize", code)))
+
+	outer := NewHooks()
+	outer.OnNew(rec.hook("outer new", code))
+	outer.OnSanitize(rec.hook("outer sanitize", code))
+
+	inner := NewHooks()
+	inner.OnNew(rec.hook("inner new", code))
+
+	ctx := ContextWithHooks(ContextWithHooks(context.Background(), outer), inner)
+
+	tests := []struct {
+		name string
+		new  func() *XErr
+		want []string
+	}{
+		{
+			name: "global hooks",
+			new:  func() *XErr { return New("some error", WithCode(code)) },
+			want: []string{"global new 1", "global new 2", "global sanitize"},
+		},
+		{
+			name: "context hooks",
+			new:  func() *XErr { return New("some error", WithCode(code), WithContext(ctx)) },
+			want: []string{
+				"global new 1", "global new 2", "outer new", "inner new",
+				"global sanitize", "outer sanitize",
+			},
+		},
+		{
+			name: "parent context hooks only",
+			new: func() *XErr {
+				return New("some error", WithCode(code), WithContext(ContextWithHooks(context.Background(), outer)))
+			},
+			want: []string{"global new 1", "global new 2", "outer new", "global sanitize", "outer sanitize"},
+		},
+	}
+
+	for _, tt := range tests {
+		rec.calls = nil
+
+		xErr := tt.new()
+		xErr.Sanitize()
+
+		if got := rec.get(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: hooks calls = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHooks_ModifyXErr(t *testing.T) {
+	t.Parallel()
+
+	hooks := NewHooks()
+	hooks.OnNew(func(err *XErr) {
+		err.InternalExtra = withValue(err.InternalExtra, "audit", true)
+	})
+
+	ctx := ContextWithHooks(context.Background(), hooks)
+
+	xErr := New("some error", WithContext(ctx), WithInternalExtra(map[string]interface{}{"error_info": "info"}))
+	want := map[string]interface{}{"error_info": "info", "audit": true}
+
+	if got := xErr.GetInternalExtra(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetInternalExtra() = %v, want %v", got, want)
+	}
+}
+
+func TestHooks_Unregister(t *testing.T) {
+	t.Parallel()
+
+	const code = "hooks_unregister_test"
+
+	rec := &hookRecorder{}
+
+	hooks := NewHooks()
+	unregister := hooks.OnNew(rec.hook("scoped", code))
+	hooks.OnNew(rec.hook("scoped kept", code))
+	unregisterGlobal := OnNew(rec.hook("global", code))
+
+	ctx := ContextWithHooks(context.Background(), hooks)
+
+	New("some error", WithCode(code), WithContext(ctx))
+
+	unregister()
+	unregister()
+	unregisterGlobal()
+
+	New("some error", WithCode(code), WithContext(ctx))
+
+	want := []string{"global", "scoped", "scoped kept", "scoped kept"}
+	if got := rec.get(); !reflect.DeepEqual(got, want) {
+		t.Errorf("hooks calls = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,40 @@
+package xerrors
+
+import "sync/atomic"
+
+// Metrics is notified about errors to count them, e.g. per code, status or severity.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ErrorCreated is called by New for every created error.
+	ErrorCreated(xErr XError)
+	// ErrorReturned is called when the error is returned to the client, e.g. by xhttp writers.
+	ErrorReturned(xErr XError)
+}
+
+type metricsHolder struct{ m Metrics }
+
+var metrics atomic.Pointer[metricsHolder] // nolint:gochecknoglobals
+
+// SetMetrics sets Metrics notified about errors, nil disables notifications.
+func SetMetrics(m Metrics) {
+	if m == nil {
+		metrics.Store(nil)
+
+		return
+	}
+
+	metrics.Store(&metricsHolder{m: m})
+}
+
+// ErrorReturned notifies Metrics set by SetMetrics that xErr is returned to the client.
+func ErrorReturned(xErr XError) {
+	if h := metrics.Load(); h != nil && !IsNil(xErr) {
+		h.m.ErrorReturned(xErr)
+	}
+}
+
+func errorCreated(xErr XError) {
+	if h := metrics.Load(); h != nil {
+		h.m.ErrorCreated(xErr)
+	}
+}
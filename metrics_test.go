@@ -0,0 +1,75 @@
+package xerrors
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+type codeMetrics struct {
+	code string
+
+	mu       sync.Mutex
+	created  int
+	returned int
+}
+
+func (m *codeMetrics) ErrorCreated(xErr XError) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if CodeKey(xErr) == m.code {
+		m.created++
+	}
+}
+
+func (m *codeMetrics) ErrorReturned(xErr XError) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if CodeKey(xErr) == m.code {
+		m.returned++
+	}
+}
+
+// nolint:paralleltest // SetMetrics changes global state.
+func TestSetMetrics(t *testing.T) {
+	m := &codeMetrics{code: "metrics_test"}
+
+	SetMetrics(m)
+	defer SetMetrics(nil)
+
+	xErr := New("some error", WithCode("metrics_test"))
+	NewXErr("some error", "", nil, nil)
+	ErrorReturned(xErr)
+	ErrorReturned((*XErr)(nil))
+
+	SetMetrics(nil)
+	New("some error", WithCode("metrics_test"))
+	ErrorReturned(xErr)
+
+	if m.created != 1 || m.returned != 1 {
+		t.Errorf("Metrics created = %v, returned = %v, want 1 and 1", m.created, m.returned)
+	}
+}
+
+// nolint:paralleltest // SetMetrics changes global state.
+func TestSetMetrics_TruncatedJSON(t *testing.T) {
+	m := &codeMetrics{code: TruncatedCode}
+
+	SetMetrics(m)
+	defer SetMetrics(nil)
+
+	xErrs := NewXErrsLimited(1)
+	xErrs.Add(New("some error"), New("some error"))
+
+	for i := 0; i < 2; i++ {
+		if _, err := json.Marshal(xErrs); err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+	}
+
+	if m.created != 0 {
+		t.Errorf("Metrics created = %v for overflow entries, want 0", m.created)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !xerrorsdebug
+
+package xerrors
+
+// debug is true when built with xerrorsdebug tag, misuse of frozen XErr panics in debug builds.
+const debug = false
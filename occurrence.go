@@ -0,0 +1,102 @@
+package xerrors
+
+import (
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// Internal extra keys of occurrence metadata captured by New when enabled with EnableOccurrence.
+const (
+	OccurredAtKey     = "occurred_at"
+	HostnameKey       = "hostname"
+	ServiceKey        = "service"
+	ServiceVersionKey = "service_version"
+)
+
+// OccurrenceConfig configures occurrence metadata captured for every XErr created with New.
+type OccurrenceConfig struct {
+	// Service is the name of the service.
+	Service string
+	// Version is the version of the service.
+	Version string
+	// Hostname is detected with os.Hostname if empty.
+	Hostname string
+	// Now returns creation time, time.Now by default.
+	Now func() time.Time
+}
+
+var occurrence atomic.Pointer[OccurrenceConfig] // nolint:gochecknoglobals
+
+// EnableOccurrence enables capturing of creation time, hostname, service name and version
+// into internal extra of every XErr created with New. It's supposed to be called once at startup.
+func EnableOccurrence(cfg OccurrenceConfig) {
+	if cfg.Hostname == "" {
+		cfg.Hostname, _ = os.Hostname()
+	}
+
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	occurrence.Store(&cfg)
+}
+
+// DisableOccurrence disables capturing of occurrence metadata.
+func DisableOccurrence() {
+	occurrence.Store(nil)
+}
+
+// OccurredAt returns creation time captured when occurrence metadata is enabled.
+func (err *XErr) OccurredAt() time.Time {
+	t, _ := InternalExtraValue[time.Time](err, OccurredAtKey)
+
+	return t
+}
+
+// Hostname returns hostname captured when occurrence metadata is enabled.
+func (err *XErr) Hostname() string {
+	s, _ := InternalExtraValue[string](err, HostnameKey)
+
+	return s
+}
+
+// Service returns service name captured when occurrence metadata is enabled.
+func (err *XErr) Service() string {
+	s, _ := InternalExtraValue[string](err, ServiceKey)
+
+	return s
+}
+
+// ServiceVersion returns service version captured when occurrence metadata is enabled.
+func (err *XErr) ServiceVersion() string {
+	s, _ := InternalExtraValue[string](err, ServiceVersionKey)
+
+	return s
+}
+
+func captureOccurrence(err *XErr) {
+	cfg := occurrence.Load()
+	if cfg == nil {
+		return
+	}
+
+	intExtra := make(map[string]interface{}, len(err.InternalExtra)+4) // nolint:gomnd
+	for k, v := range err.InternalExtra {
+		intExtra[k] = v
+	}
+
+	intExtra[OccurredAtKey] = cfg.Now()
+
+	for key, v := range map[string]string{
+		HostnameKey:       cfg.Hostname,
+		ServiceKey:        cfg.Service,
+		ServiceVersionKey: cfg.Version,
+	} {
+		if v != "" {
+			intExtra[key] = v
+		}
+	}
+
+	err.InternalExtra = intExtra
+}
@@ -0,0 +1,78 @@
+package xerrors
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// nolint:paralleltest // EnableOccurrence changes global state.
+func TestEnableOccurrence(t *testing.T) {
+	now := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	EnableOccurrence(OccurrenceConfig{
+		Service:  "svc",
+		Version:  "1.2.3",
+		Hostname: "host-1",
+		Now:      func() time.Time { return now },
+	})
+	defer DisableOccurrence()
+
+	cause := errors.New("cause")
+	intExtra := map[string]interface{}{"error": cause}
+
+	xErr := New("test msg", WithInternalExtra(intExtra))
+
+	if got := xErr.OccurredAt(); !got.Equal(now) {
+		t.Errorf("OccurredAt() = %v, want %v", got, now)
+	}
+
+	if got := xErr.Hostname(); got != "host-1" {
+		t.Errorf("Hostname() = %v, want host-1", got)
+	}
+
+	if got := xErr.Service(); got != "svc" {
+		t.Errorf("Service() = %v, want svc", got)
+	}
+
+	if got := xErr.ServiceVersion(); got != "1.2.3" {
+		t.Errorf("ServiceVersion() = %v, want 1.2.3", got)
+	}
+
+	if got := xErr.Unwrap(); got != cause {
+		t.Errorf("Unwrap() = %v, want %v", got, cause)
+	}
+
+	if len(intExtra) != 1 {
+		t.Errorf("caller's internal extra was modified: %v", intExtra)
+	}
+
+	DisableOccurrence()
+
+	xErr = New("test msg")
+
+	if got := xErr.OccurredAt(); !got.IsZero() {
+		t.Errorf("OccurredAt() after DisableOccurrence = %v, want zero", got)
+	}
+
+	if got := xErr.GetInternalExtra(); got != nil {
+		t.Errorf("GetInternalExtra() after DisableOccurrence = %v, want nil", got)
+	}
+}
+
+// nolint:paralleltest // EnableOccurrence changes global state.
+func TestEnableOccurrence_Defaults(t *testing.T) {
+	EnableOccurrence(OccurrenceConfig{Service: "svc"})
+	defer DisableOccurrence()
+
+	before := time.Now()
+	xErr := New("test msg")
+
+	if got := xErr.OccurredAt(); got.Before(before) || got.After(time.Now()) {
+		t.Errorf("OccurredAt() = %v, want current time", got)
+	}
+
+	if _, ok := xErr.GetInternalExtra()[ServiceVersionKey]; ok {
+		t.Errorf("GetInternalExtra() has empty %q", ServiceVersionKey)
+	}
+}
@@ -0,0 +1,136 @@
+package xerrors
+
+import "sort"
+
+// CodeKey returns error code if xErr implements GetCode, e.g. XErr, or empty string otherwise.
+// It can be used as a key for XErrs.GroupBy.
+func CodeKey(xErr XError) string {
+	if IsNil(xErr) {
+		return ""
+	}
+
+	if c, ok := xErr.(interface{ GetCode() string }); ok {
+		return c.GetCode()
+	}
+
+	return ""
+}
+
+// Filter returns new XErrs with errors that satisfy pred.
+func (errs *XErrs) Filter(pred func(XError) bool) *XErrs {
+	if errs == nil {
+		return nil
+	}
+
+	filtered := NewXErrsWithLen(0, len(errs.Errs))
+
+	for _, xErr := range errs.Errs {
+		if pred(xErr) {
+			filtered.Add(xErr)
+		}
+	}
+
+	return filtered
+}
+
+// GroupBy splits errors collection into groups by the key returned from key func, e.g. CodeKey.
+// Errors order inside each group is preserved.
+func (errs *XErrs) GroupBy(key func(XError) string) map[string]*XErrs {
+	if errs == nil {
+		return nil
+	}
+
+	groups := make(map[string]*XErrs)
+
+	for _, xErr := range errs.Errs {
+		k := key(xErr)
+		if groups[k] == nil {
+			groups[k] = NewXErrs()
+		}
+
+		groups[k].Add(xErr)
+	}
+
+	return groups
+}
+
+// First returns the first error in collection or nil if collection is empty.
+func (errs *XErrs) First() XError {
+	if errs.Len() == 0 {
+		return nil
+	}
+
+	return errs.Errs[0]
+}
+
+// Find returns the first error with the given code or nil if there is no such error.
+func (errs *XErrs) Find(code string) XError {
+	if errs == nil {
+		return nil
+	}
+
+	for _, xErr := range errs.Errs {
+		if CodeKey(xErr) == code {
+			return xErr
+		}
+	}
+
+	return nil
+}
+
+// Has reports whether collection contains an error with the given code.
+func (errs *XErrs) Has(code string) bool {
+	return errs.Find(code) != nil
+}
+
+// Dedup returns new XErrs without errors that have the same code and message as a preceding error.
+func (errs *XErrs) Dedup() *XErrs {
+	if errs == nil {
+		return nil
+	}
+
+	type dedupKey struct{ code, msg string }
+
+	seen := make(map[dedupKey]struct{}, len(errs.Errs))
+	deduped := NewXErrsWithLen(0, len(errs.Errs))
+
+	for _, xErr := range errs.Errs {
+		k := dedupKey{code: CodeKey(xErr)}
+		if !IsNil(xErr) {
+			k.msg = xErr.GetMessage()
+		}
+
+		if _, ok := seen[k]; ok {
+			continue
+		}
+
+		seen[k] = struct{}{}
+		deduped.Add(xErr)
+	}
+
+	return deduped
+}
+
+// Sort sorts errors collection in place using less func, the order of equal errors is preserved.
+func (errs *XErrs) Sort(less func(a, b XError) bool) {
+	if errs == nil {
+		return
+	}
+
+	sort.SliceStable(errs.Errs, func(i, j int) bool { return less(errs.Errs[i], errs.Errs[j]) })
+}
+
+// Summary returns number of errors per code.
+func (errs *XErrs) Summary() map[string]int {
+	if errs == nil {
+		return nil
+	}
+
+	summary := make(map[string]int)
+
+	for _, xErr := range errs.Errs {
+		summary[CodeKey(xErr)]++
+	}
+
+	return summary
+}
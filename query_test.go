@@ -0,0 +1,219 @@
+// nolint:funlen
+package xerrors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newCodedErrs() (*XErrs, []XError) {
+	errs := []XError{
+		New("user not found", WithCode("not_found")),
+		New("invalid email", WithCode("invalid")),
+		New("order not found", WithCode("not_found")),
+		New("invalid email", WithCode("invalid")),
+		New("no code"),
+	}
+
+	return &XErrs{Errs: append([]XError(nil), errs...)}, errs
+}
+
+func TestXErrs_Filter(t *testing.T) {
+	t.Parallel()
+
+	xErrs, errs := newCodedErrs()
+
+	tests := []struct {
+		name  string
+		xerrs *XErrs
+		pred  func(XError) bool
+		want  *XErrs
+	}{
+		{
+			name:  "nil XErrs",
+			xerrs: nil,
+			pred:  func(XError) bool { return true },
+			want:  nil,
+		},
+		{
+			name:  "nothing matched",
+			xerrs: xErrs,
+			pred:  func(XError) bool { return false },
+			want:  &XErrs{Errs: make([]XError, 0, len(errs))},
+		},
+		{
+			name:  "filter by code",
+			xerrs: xErrs,
+			pred:  func(xErr XError) bool { return CodeKey(xErr) == "not_found" },
+			want:  &XErrs{Errs: []XError{errs[0], errs[2]}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.xerrs.Filter(tt.pred); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXErrs_GroupBy(t *testing.T) {
+	t.Parallel()
+
+	xErrs, errs := newCodedErrs()
+
+	got := xErrs.GroupBy(CodeKey)
+	want := map[string]*XErrs{
+		"not_found": {Errs: []XError{errs[0], errs[2]}},
+		"invalid":   {Errs: []XError{errs[1], errs[3]}},
+		"":          {Errs: []XError{errs[4]}},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupBy() = %v, want %v", got, want)
+	}
+
+	if got := (*XErrs)(nil).GroupBy(CodeKey); got != nil {
+		t.Errorf("GroupBy() = %v, want nil", got)
+	}
+}
+
+func TestXErrs_Find(t *testing.T) {
+	t.Parallel()
+
+	xErrs, errs := newCodedErrs()
+
+	tests := []struct {
+		name    string
+		xerrs   *XErrs
+		code    string
+		want    XError
+		wantHas bool
+	}{
+		{
+			name:    "nil XErrs",
+			xerrs:   nil,
+			code:    "not_found",
+			want:    nil,
+			wantHas: false,
+		},
+		{
+			name:    "found",
+			xerrs:   xErrs,
+			code:    "invalid",
+			want:    errs[1],
+			wantHas: true,
+		},
+		{
+			name:    "not found",
+			xerrs:   xErrs,
+			code:    "conflict",
+			want:    nil,
+			wantHas: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.xerrs.Find(tt.code); got != tt.want {
+				t.Errorf("Find() = %v, want %v", got, tt.want)
+			}
+
+			if got := tt.xerrs.Has(tt.code); got != tt.wantHas {
+				t.Errorf("Has() = %v, want %v", got, tt.wantHas)
+			}
+		})
+	}
+}
+
+func TestXErrs_First(t *testing.T) {
+	t.Parallel()
+
+	xErrs, errs := newCodedErrs()
+
+	tests := []struct {
+		name  string
+		xerrs *XErrs
+		want  XError
+	}{
+		{
+			name:  "nil XErrs",
+			xerrs: nil,
+			want:  nil,
+		},
+		{
+			name:  "no errors",
+			xerrs: &XErrs{},
+			want:  nil,
+		},
+		{
+			name:  "multiple errors",
+			xerrs: xErrs,
+			want:  errs[0],
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.xerrs.First(); got != tt.want {
+				t.Errorf("First() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXErrs_Dedup(t *testing.T) {
+	t.Parallel()
+
+	xErrs, errs := newCodedErrs()
+
+	want := &XErrs{Errs: []XError{errs[0], errs[1], errs[2], errs[4]}}
+	if got := xErrs.Dedup(); !reflect.DeepEqual(got.Errs, want.Errs) {
+		t.Errorf("Dedup() = %v, want %v", got, want)
+	}
+
+	if got := xErrs.Len(); got != len(errs) {
+		t.Errorf("Dedup() modified source collection, Len() = %v, want %v", got, len(errs))
+	}
+}
+
+func TestXErrs_Sort(t *testing.T) {
+	t.Parallel()
+
+	xErrs, errs := newCodedErrs()
+
+	xErrs.Sort(func(a, b XError) bool { return CodeKey(a) < CodeKey(b) })
+
+	want := []XError{errs[4], errs[1], errs[3], errs[0], errs[2]}
+	if !reflect.DeepEqual(xErrs.Errs, want) {
+		t.Errorf("Sort() = %v, want %v", xErrs.Errs, want)
+	}
+}
+
+func TestXErrs_Summary(t *testing.T) {
+	t.Parallel()
+
+	xErrs, _ := newCodedErrs()
+
+	want := map[string]int{"not_found": 2, "invalid": 2, "": 1}
+	if got := xErrs.Summary(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Summary() = %v, want %v", got, want)
+	}
+
+	if got := (*XErrs)(nil).Summary(); got != nil {
+		t.Errorf("Summary() = %v, want nil", got)
+	}
+}
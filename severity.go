@@ -0,0 +1,76 @@
+package xerrors
+
+import "strconv"
+
+// Severity represents how serious the error is, e.g. to choose log level or alerting.
+type Severity int
+
+const (
+	SeverityDebug Severity = iota + 1
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
+// DefaultSeverity is the severity of errors created without WithSeverity option.
+const DefaultSeverity = SeverityError
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityDebug:
+		return "debug"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "severity(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// Kind represents class of the error.
+type Kind string
+
+const (
+	KindValidation Kind = "validation"
+	KindNotFound   Kind = "not_found"
+	KindConflict   Kind = "conflict"
+	KindAuth       Kind = "auth"
+	KindTransient  Kind = "transient"
+	KindInternal   Kind = "internal"
+)
+
+// BySeverity orders errors from the highest severity to the lowest, it can be used with XErrs.Sort.
+func BySeverity(a, b XError) bool {
+	return severityOf(a) > severityOf(b)
+}
+
+// HighestSeverity returns the highest severity of errors in collection or 0 if collection is empty.
+func (errs *XErrs) HighestSeverity() Severity {
+	if errs == nil {
+		return 0
+	}
+
+	var highest Severity
+
+	for _, xErr := range errs.Errs {
+		if s := severityOf(xErr); s > highest {
+			highest = s
+		}
+	}
+
+	return highest
+}
+
+func severityOf(xErr XError) Severity {
+	if IsNil(xErr) {
+		return 0
+	}
+
+	return xErr.GetSeverity()
+}
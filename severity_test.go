@@ -0,0 +1,130 @@
+package xerrors
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSeverity_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		severity Severity
+		want     string
+	}{
+		{name: "debug", severity: SeverityDebug, want: "debug"},
+		{name: "info", severity: SeverityInfo, want: "info"},
+		{name: "warning", severity: SeverityWarning, want: "warning"},
+		{name: "error", severity: SeverityError, want: "error"},
+		{name: "critical", severity: SeverityCritical, want: "critical"},
+		{name: "unknown", severity: Severity(42), want: "severity(42)"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.severity.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXErr_GetSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		xErr     *XErr
+		want     Severity
+		wantKind Kind
+	}{
+		{
+			name:     "nil XErr",
+			xErr:     nil,
+			want:     DefaultSeverity,
+			wantKind: "",
+		},
+		{
+			name:     "without severity and kind",
+			xErr:     New("test message"),
+			want:     DefaultSeverity,
+			wantKind: "",
+		},
+		{
+			name:     "with severity and kind",
+			xErr:     New("test message", WithSeverity(SeverityCritical), WithKind(KindTransient)),
+			want:     SeverityCritical,
+			wantKind: KindTransient,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.xErr.GetSeverity(); got != tt.want {
+				t.Errorf("GetSeverity() = %v, want %v", got, tt.want)
+			}
+
+			if got := tt.xErr.GetKind(); got != tt.wantKind {
+				t.Errorf("GetKind() = %v, want %v", got, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestXErrs_HighestSeverity(t *testing.T) {
+	t.Parallel()
+
+	info := New("info", WithSeverity(SeverityInfo))
+	critical := New("critical", WithSeverity(SeverityCritical))
+	warning := New("warning", WithSeverity(SeverityWarning))
+
+	tests := []struct {
+		name  string
+		xerrs *XErrs
+		want  Severity
+	}{
+		{
+			name:  "nil XErrs",
+			xerrs: nil,
+			want:  0,
+		},
+		{
+			name:  "no errors",
+			xerrs: &XErrs{},
+			want:  0,
+		},
+		{
+			name:  "multiple errors",
+			xerrs: &XErrs{Errs: []XError{info, critical, warning}},
+			want:  SeverityCritical,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.xerrs.HighestSeverity(); got != tt.want {
+				t.Errorf("HighestSeverity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	xErrs := &XErrs{Errs: []XError{info, critical, warning}}
+	xErrs.Sort(BySeverity)
+
+	if want := []XError{critical, warning, info}; !reflect.DeepEqual(xErrs.Errs, want) {
+		t.Errorf("Sort(BySeverity) = %v, want %v", xErrs.Errs, want)
+	}
+}
@@ -0,0 +1,80 @@
+package xerrors
+
+// Template is an immutable prototype of XErr, e.g. a package-level definition of the error.
+// Unlike a shared *XErr instance it can't be modified by Sanitize or options,
+// each Template.New call returns new XErr that shares no maps with the template and other instances.
+// Instances match the template with errors.Is.
+// The zero value is a template of XErr with empty message, use NewTemplate to create templates.
+type Template struct {
+	proto *XErr
+}
+
+// NewTemplate returns new Template of XErr with the message and options applied.
+// Options are applied once, hooks and metrics are called only for instances created with Template.New.
+// Options deferred until the end of New, e.g. WithFingerprint, are applied to each instance.
+// Stack trace captured by WithStack is not copied to instances, pass WithStack to Template.New instead.
+func NewTemplate(msg string, opts ...XErrOpt) *Template {
+	proto := &XErr{Message: msg}
+
+	for _, opt := range opts {
+		opt(proto)
+	}
+
+	proto.Extra = merge(nil, proto.Extra, true)
+	proto.InternalExtra = merge(nil, proto.InternalExtra, true)
+
+	return &Template{proto: proto}
+}
+
+// Template returns new Template with the builder message and options.
+func (b Builder) Template() *Template {
+	return NewTemplate(b.msg, b.opts...)
+}
+
+// New returns new XErr with fields of the template, cause stored in internal extra under the "error" key
+// and opts applied after them.
+func (t *Template) New(cause error, opts ...XErrOpt) *XErr {
+	all := make([]XErrOpt, 0, len(opts)+2) // nolint:gomnd
+	all = append(all, t.apply)
+
+	if cause != nil {
+		all = append(all, WithInternalExtraField("error", cause))
+	}
+
+	return New(t.prototype().Message, append(all, opts...)...)
+}
+
+// Error returns message of the template, so it can be used as errors.Is target.
+func (t *Template) Error() string {
+	return t.prototype().Message
+}
+
+// apply copies template fields to err.
+func (t *Template) apply(err *XErr) {
+	proto := t.prototype()
+
+	*err = *proto
+
+	err.Extra = merge(nil, proto.Extra, true)
+	err.InternalExtra = merge(nil, proto.InternalExtra, true)
+	err.stack = nil
+	err.scoped = append([]*Hooks(nil), proto.scoped...)
+	err.deferred = append([]XErrOpt(nil), proto.deferred...)
+	err.template = t
+}
+
+// prototype returns XErr the template was created with, or empty XErr for the zero value.
+func (t *Template) prototype() *XErr {
+	if t.proto == nil {
+		return &XErr{}
+	}
+
+	return t.proto
+}
+
+// Is reports whether err is an instance of target Template, it's used by errors.Is.
+func (err *XErr) Is(target error) bool {
+	t, ok := target.(*Template)
+
+	return ok && err != nil && t != nil && err.template == t
+}
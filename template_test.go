@@ -0,0 +1,180 @@
+// nolint:funlen,goerr113
+package xerrors
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTemplate_New(t *testing.T) {
+	t.Parallel()
+
+	extra := map[string]interface{}{"http_code": 404}
+	tmpl := NewTemplate("user not found",
+		WithCode("user_not_found"),
+		WithKind(KindNotFound),
+		WithExtra(extra),
+		WithInternalExtra(map[string]interface{}{"table": "users"}),
+	)
+
+	extra["http_code"] = 500
+
+	cause := errors.New("cause")
+
+	tests := []struct {
+		name  string
+		cause error
+		opts  []XErrOpt
+		want  *XErr
+	}{
+		{
+			name:  "without cause",
+			cause: nil,
+			want: &XErr{
+				Code:          "user_not_found",
+				Message:       "user not found",
+				Kind:          KindNotFound,
+				Extra:         map[string]interface{}{"http_code": 404},
+				InternalExtra: map[string]interface{}{"table": "users"},
+				template:      tmpl,
+			},
+		},
+		{
+			name:  "with cause and options",
+			cause: cause,
+			opts:  []XErrOpt{WithDescription("test descr"), WithExtraField("id", 42)},
+			want: &XErr{
+				Code:          "user_not_found",
+				Message:       "user not found",
+				Description:   "test descr",
+				Kind:          KindNotFound,
+				Extra:         map[string]interface{}{"http_code": 404, "id": 42},
+				InternalExtra: map[string]interface{}{"table": "users", "error": cause},
+				template:      tmpl,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tmpl.New(tt.cause, tt.opts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("New() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplate_Independent(t *testing.T) {
+	t.Parallel()
+
+	tmpl := NewTemplate("test msg", WithExtra(map[string]interface{}{"key": "value"}), WithFingerprint())
+
+	xErr1 := tmpl.New(nil)
+	xErr1.Extra["key"] = "modified"
+	xErr1.Sanitize()
+
+	xErr2 := tmpl.New(nil, WithDescription("test descr"))
+
+	if got := xErr2.Extra["key"]; got != "value" {
+		t.Errorf("New().Extra[key] = %v after modifying another instance, want value", got)
+	}
+
+	if got := xErr2.GetDescription(); got != "test descr" {
+		t.Errorf("New().GetDescription() = %v, want test descr", got)
+	}
+
+	if _, ok := xErr2.InternalExtra[FingerprintKey]; !ok {
+		t.Errorf("New() has no fingerprint set by template option")
+	}
+}
+
+func TestXErr_Is(t *testing.T) {
+	t.Parallel()
+
+	tmpl := NewTemplate("test msg")
+	other := NewTemplate("test msg")
+	cause := errors.New("cause")
+
+	xErr := tmpl.New(cause)
+
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{
+			name:   "own template",
+			err:    xErr,
+			target: tmpl,
+			want:   true,
+		},
+		{
+			name:   "other template",
+			err:    xErr,
+			target: other,
+			want:   false,
+		},
+		{
+			name:   "built from builder",
+			err:    Build("test msg").Template().New(nil),
+			target: tmpl,
+			want:   false,
+		},
+		{
+			name:   "without template",
+			err:    New("test msg"),
+			target: tmpl,
+			want:   false,
+		},
+		{
+			name:   "cause",
+			err:    xErr,
+			target: cause,
+			want:   true,
+		},
+		{
+			name:   "in collection",
+			err:    Join(New("other"), xErr),
+			target: tmpl,
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := errors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplate_ZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var tmpl Template
+
+	xErr := tmpl.New(errors.New("cause"), WithCode("test_code"))
+
+	if got := tmpl.Error(); got != "" {
+		t.Errorf("Error() = %q, want empty", got)
+	}
+
+	if xErr.GetMessage() != "" || xErr.GetCode() != "test_code" || xErr.Unwrap() == nil {
+		t.Errorf("New() = %v, want error with code and cause", xErr)
+	}
+
+	if !errors.Is(xErr, &tmpl) {
+		t.Errorf("errors.Is(New(), template) = false, want true")
+	}
+}
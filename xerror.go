@@ -1,6 +1,10 @@
 package xerrors
 
-import "fmt"
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
 
 // Error - base interface that contains minimum amount of required functions.
 type Error interface {
@@ -18,6 +22,10 @@ type Error interface {
 type XError interface {
 	Error
 
+	// GetSeverity returns error severity.
+	GetSeverity() Severity
+	// GetKind returns error class.
+	GetKind() Kind
 	// GetExtra returns public extra info.
 	GetExtra() map[string]interface{}
 	// GetInternalExtra returns private extra info.
@@ -26,29 +34,63 @@ type XError interface {
 
 // XErr represents extended error.
 type XErr struct {
+	// Code contains machine-readable error code, e.g. "user_not_found".
+	Code string `json:"code,omitempty"`
 	// Message contains general error message.
 	Message string `json:"message,omitempty"`
 	// Description contains detailed error description.
 	Description string `json:"description,omitempty"`
 
+	// Severity contains error severity, DefaultSeverity is used if not set.
+	Severity Severity `json:"-"`
+	// Kind contains error class, e.g. validation or not found.
+	Kind Kind `json:"-"`
+
 	// Extra contains any public extra info that can be sent in the response.
 	Extra map[string]interface{} `json:"extra,omitempty"`
 	// InternalExtra contains private extra info that could be helpful for internal usage
 	// and shouldn't be sent to external users.
 	InternalExtra map[string]interface{} `json:"-"`
+
+	retryable  bool
+	retryAfter time.Duration
+	stack      []uintptr
+	scoped     []*Hooks
+	template   *Template
+	frozen     bool
+	// concealed is set by Sanitize of frozen XErr, description is hidden from public views then.
+	concealed *atomic.Bool
+
+	// deferred contains options that New applies after all other options.
+	deferred []XErrOpt
 }
 
 // XErrOpt represents option for XErr constructor New.
 type XErrOpt func(err *XErr)
 
+func WithCode(code string) XErrOpt                   { return func(err *XErr) { err.Code = code } }
 func WithMessage(msg string) XErrOpt                 { return func(err *XErr) { err.Message = msg } }
 func WithDescription(descr string) XErrOpt           { return func(err *XErr) { err.Description = descr } }
+func WithSeverity(s Severity) XErrOpt                { return func(err *XErr) { err.Severity = s } }
+func WithKind(kind Kind) XErrOpt                     { return func(err *XErr) { err.Kind = kind } }
 func WithExtra(extra map[string]interface{}) XErrOpt { return func(err *XErr) { err.Extra = extra } }
 func WithInternalExtra(extra map[string]interface{}) XErrOpt {
 	return func(err *XErr) { err.InternalExtra = extra }
 }
 
+// WithRetryable marks error as retryable or not.
+func WithRetryable(retryable bool) XErrOpt { return func(err *XErr) { err.retryable = retryable } }
+
+// WithRetryAfter sets delay before the next retry and marks error as retryable.
+func WithRetryAfter(d time.Duration) XErrOpt {
+	return func(err *XErr) {
+		err.retryable = true
+		err.retryAfter = d
+	}
+}
+
 // New - constructor for XErr with options, returns new *XErr.
+// OnNew hooks are invoked after all options are applied.
 func New(msg string, opts ...XErrOpt) *XErr {
 	err := &XErr{
 		Message: msg,
@@ -58,6 +100,18 @@ func New(msg string, opts ...XErrOpt) *XErr {
 		opt(err)
 	}
 
+	for len(err.deferred) > 0 {
+		opt := err.deferred[0]
+		err.deferred = err.deferred[1:]
+		opt(err)
+	}
+
+	err.deferred = nil
+
+	captureOccurrence(err)
+	runNewHooks(err)
+	errorCreated(err)
+
 	return err
 }
 
@@ -66,21 +120,42 @@ func NewXErr(msg, descr string, extra, intExtra map[string]interface{}) *XErr {
 	return New(msg, WithDescription(descr), WithExtra(extra), WithInternalExtra(intExtra))
 }
 
+// IsNil reports whether xErr is nil or holds nil *XErr, see Caveats in README.
+func IsNil(xErr XError) bool {
+	if xErr == nil {
+		return true
+	}
+
+	err, ok := xErr.(*XErr)
+
+	return ok && err == nil
+}
+
 // Error unifying XErr with Go error interface.
 func (err *XErr) Error() string {
 	if err == nil {
 		return ""
 	}
 
-	return fmt.Sprintf("%s: %s; %v", err.Message, err.Description, err.Extra)
+	return fmt.Sprintf("%s: %s; %v", err.Message, err.GetDescription(), err.Extra)
 }
 
+// Sanitize removes sensitive information from XErr and runs sanitize hooks.
+// Frozen XErr is not modified, see Freeze.
 func (err *XErr) Sanitize() {
 	if err == nil {
 		return
 	}
 
+	if err.frozen {
+		err.conceal()
+
+		return
+	}
+
 	err.Description = ""
+
+	runSanitizeHooks(err)
 }
 
 func (err *XErr) GetMessage() string {
@@ -92,25 +167,107 @@ func (err *XErr) GetMessage() string {
 }
 
 func (err *XErr) GetDescription() string {
-	if err == nil {
+	if err == nil || err.isConcealed() {
 		return ""
 	}
 
 	return err.Description
 }
 
+// GetCode returns machine-readable error code.
+func (err *XErr) GetCode() string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Code
+}
+
+func (err *XErr) GetSeverity() Severity {
+	if err == nil || err.Severity == 0 {
+		return DefaultSeverity
+	}
+
+	return err.Severity
+}
+
+func (err *XErr) GetKind() Kind {
+	if err == nil {
+		return ""
+	}
+
+	return err.Kind
+}
+
+// Retryable reports whether the failed operation can be retried.
+func (err *XErr) Retryable() bool {
+	if err == nil {
+		return false
+	}
+
+	return err.retryable
+}
+
+// RetryAfter returns delay before the next retry or 0 if there is no hint.
+func (err *XErr) RetryAfter() time.Duration {
+	if err == nil {
+		return 0
+	}
+
+	return err.retryAfter
+}
+
+// GetExtra returns public extra info, copy is returned for frozen XErr.
 func (err *XErr) GetExtra() map[string]interface{} {
 	if err == nil {
 		return nil
 	}
 
+	if err.frozen {
+		return merge(nil, err.Extra, true)
+	}
+
 	return err.Extra
 }
 
+// Clone returns copy of err with copied extra maps, values stored in the maps are not copied.
+func (err *XErr) Clone() *XErr {
+	if err == nil {
+		return nil
+	}
+
+	cp := *err
+	cp.Extra = merge(nil, err.Extra, true)
+	cp.InternalExtra = merge(nil, err.InternalExtra, true)
+
+	if err.concealed != nil {
+		cp.concealed = new(atomic.Bool)
+		cp.concealed.Store(err.concealed.Load())
+	}
+
+	return &cp
+}
+
+// Unwrap returns the cause stored in internal extra under the "error" key, if any.
+func (err *XErr) Unwrap() error {
+	if err == nil {
+		return nil
+	}
+
+	cause, _ := err.InternalExtra["error"].(error)
+
+	return cause
+}
+
+// GetInternalExtra returns private extra info, copy is returned for frozen XErr.
 func (err *XErr) GetInternalExtra() map[string]interface{} {
 	if err == nil {
 		return nil
 	}
 
+	if err.frozen {
+		return merge(nil, err.InternalExtra, true)
+	}
+
 	return err.InternalExtra
 }
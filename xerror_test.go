@@ -1,10 +1,12 @@
-// nolint:dupl,funlen
+// nolint:dupl,funlen,goerr113
 package xerrors
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestXErr_GetDescription(t *testing.T) {
@@ -380,3 +382,172 @@ func TestXErr_MarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestXErr_Unwrap(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("db connection failed")
+
+	tests := []struct {
+		name string
+		xErr *XErr
+		want error
+	}{
+		{
+			name: "nil XErr",
+			xErr: nil,
+			want: nil,
+		},
+		{
+			name: "without cause",
+			xErr: NewXErr("test message", "", nil, map[string]interface{}{"error_info": "connect to db"}),
+			want: nil,
+		},
+		{
+			name: "with cause",
+			xErr: NewXErr("test message", "", nil, map[string]interface{}{"error": cause}),
+			want: cause,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.xErr.Unwrap(); got != tt.want { // nolint:errorlint
+				t.Errorf("Unwrap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXErr_GetCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		xErr *XErr
+		want string
+	}{
+		{
+			name: "nil XErr",
+			xErr: nil,
+			want: "",
+		},
+		{
+			name: "not nil XErr",
+			xErr: New("test message", WithCode("test_code")),
+			want: "test_code",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.xErr.GetCode(); got != tt.want {
+				t.Errorf("GetCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXErr_Retryable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		xErr           *XErr
+		wantRetryable  bool
+		wantRetryAfter time.Duration
+	}{
+		{
+			name:           "nil XErr",
+			xErr:           nil,
+			wantRetryable:  false,
+			wantRetryAfter: 0,
+		},
+		{
+			name:           "not retryable",
+			xErr:           New("test message"),
+			wantRetryable:  false,
+			wantRetryAfter: 0,
+		},
+		{
+			name:           "retryable",
+			xErr:           New("test message", WithRetryable(true)),
+			wantRetryable:  true,
+			wantRetryAfter: 0,
+		},
+		{
+			name:           "retry after",
+			xErr:           New("test message", WithRetryAfter(time.Minute)),
+			wantRetryable:  true,
+			wantRetryAfter: time.Minute,
+		},
+		{
+			name:           "retry after overwritten",
+			xErr:           New("test message", WithRetryAfter(time.Minute), WithRetryable(false)),
+			wantRetryable:  false,
+			wantRetryAfter: time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.xErr.Retryable(); got != tt.wantRetryable {
+				t.Errorf("Retryable() = %v, want %v", got, tt.wantRetryable)
+			}
+
+			if got := tt.xErr.RetryAfter(); got != tt.wantRetryAfter {
+				t.Errorf("RetryAfter() = %v, want %v", got, tt.wantRetryAfter)
+			}
+		})
+	}
+}
+
+func TestIsNil(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		xErr XError
+		want bool
+	}{
+		{
+			name: "nil XError",
+			xErr: nil,
+			want: true,
+		},
+		{
+			name: "nil XErr",
+			xErr: (*XErr)(nil),
+			want: true,
+		},
+		{
+			name: "not nil XErr",
+			xErr: New("test message"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsNil(tt.xErr); got != tt.want {
+				t.Errorf("IsNil() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,13 @@
 package xerrors // nolint:dupl
 
-import "strings"
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// TruncatedCode is the code of the entry that replaces errors dropped from limited XErrs in JSON.
+const TruncatedCode = "truncated"
 
 type XErrors interface {
 	error
@@ -25,8 +32,34 @@ func NewXErrsWithLen(l, c int) *XErrs {
 	return &XErrs{Errs: make([]XError, l, c)}
 }
 
+// NewXErrsLimited returns new instance of XErrs that keeps at most max errors.
+// Errors added after the limit is reached are dropped and only counted per code.
+// Non-positive max means no limit.
+func NewXErrsLimited(max int) *XErrs {
+	xErrs := NewXErrs()
+	if max > 0 {
+		xErrs.limit = max
+	}
+
+	return xErrs
+}
+
+// Join returns new instance of XErrs that contains all non-nil errs.
+// Nested XErrors and multi-errors (e.g. returned by errors.Join) are flattened into one collection,
+// errors dropped from limited XErrs are kept as dropped errors of the result,
+// errors that don't implement XError are wrapped with New and kept as a cause in internal extra.
+func Join(errs ...error) *XErrs {
+	xErrs := NewXErrsWithLen(0, len(errs))
+	xErrs.join(errs...)
+
+	return xErrs
+}
+
 type XErrs struct {
 	Errs []XError `json:"errors"`
+
+	limit   int
+	dropped map[string]int
 }
 
 func (errs *XErrs) Error() string {
@@ -34,19 +67,102 @@ func (errs *XErrs) Error() string {
 		return ""
 	}
 
-	errors := make([]string, len(errs.Errs))
+	errors := make([]string, len(errs.Errs), len(errs.Errs)+1)
 
 	for i := range errs.Errs {
 		errors[i] = errs.Errs[i].Error()
 	}
 
+	if errs.Truncated() {
+		errors = append(errors, errs.overflowMessage())
+	}
+
 	return strings.Join(errors, ";")
 }
 
+// MarshalJSON encodes errors collection, limited XErrs with dropped errors get
+// an extra entry with TruncatedCode code and number of dropped errors per code.
+func (errs *XErrs) MarshalJSON() ([]byte, error) {
+	type xErrs XErrs
+
+	if !errs.Truncated() {
+		return json.Marshal((*xErrs)(errs))
+	}
+
+	all := make([]XError, len(errs.Errs), len(errs.Errs)+1)
+	copy(all, errs.Errs)
+
+	// the entry is not a real error, so it's not created with New to skip hooks and metrics.
+	overflow := &XErr{
+		Code:    TruncatedCode,
+		Message: errs.overflowMessage(),
+		Extra:   map[string]interface{}{"dropped": errs.DroppedByCode()},
+	}
+
+	return json.Marshal(&xErrs{Errs: append(all, overflow)})
+}
+
 func (errs *XErrs) Add(xerrs ...XError) {
-	if errs != nil {
+	if errs == nil {
+		return
+	}
+
+	if errs.limit <= 0 {
 		errs.Errs = append(errs.Errs, xerrs...)
+
+		return
 	}
+
+	for _, xErr := range xerrs {
+		if len(errs.Errs) < errs.limit {
+			errs.Errs = append(errs.Errs, xErr)
+
+			continue
+		}
+
+		if errs.dropped == nil {
+			errs.dropped = make(map[string]int)
+		}
+
+		errs.dropped[CodeKey(xErr)]++
+	}
+}
+
+// Truncated reports whether some errors were dropped because of the limit.
+func (errs *XErrs) Truncated() bool {
+	return errs.Dropped() > 0
+}
+
+// Dropped returns number of errors dropped because of the limit.
+func (errs *XErrs) Dropped() int {
+	if errs == nil {
+		return 0
+	}
+
+	dropped := 0
+	for _, n := range errs.dropped {
+		dropped += n
+	}
+
+	return dropped
+}
+
+// DroppedByCode returns number of errors dropped because of the limit per code.
+func (errs *XErrs) DroppedByCode() map[string]int {
+	if errs == nil || len(errs.dropped) == 0 {
+		return nil
+	}
+
+	dropped := make(map[string]int, len(errs.dropped))
+	for code, n := range errs.dropped {
+		dropped[code] = n
+	}
+
+	return dropped
+}
+
+func (errs *XErrs) overflowMessage() string {
+	return "and " + strconv.Itoa(errs.Dropped()) + " more"
 }
 
 func (errs *XErrs) GetErrors() []XError {
@@ -65,12 +181,76 @@ func (errs *XErrs) Len() int {
 	return len(errs.Errs)
 }
 
+// Sanitize sanitizes errors collection, frozen XErr items are replaced with sanitized copies.
 func (errs *XErrs) Sanitize() {
 	if errs == nil {
 		return
 	}
 
 	for i := range errs.Errs {
+		if xErr, ok := errs.Errs[i].(*XErr); ok && xErr.IsFrozen() {
+			errs.Errs[i] = xErr.Sanitized()
+
+			continue
+		}
+
 		errs.Errs[i].Sanitize()
 	}
 }
+
+// Unwrap returns errors collection, so errors.Is and errors.As can inspect every error in it.
+func (errs *XErrs) Unwrap() []error {
+	if errs == nil || len(errs.Errs) == 0 {
+		return nil
+	}
+
+	unwrapped := make([]error, 0, len(errs.Errs))
+
+	for i := range errs.Errs {
+		if !IsNil(errs.Errs[i]) {
+			unwrapped = append(unwrapped, errs.Errs[i])
+		}
+	}
+
+	return unwrapped
+}
+
+func (errs *XErrs) join(joined ...error) {
+	for _, err := range joined {
+		if err == nil {
+			continue
+		}
+
+		switch e := err.(type) { // nolint:errorlint
+		case XErrors:
+			for _, xErr := range e.GetErrors() {
+				if !IsNil(xErr) {
+					errs.Add(xErr)
+				}
+			}
+
+			if d, ok := e.(interface{ DroppedByCode() map[string]int }); ok {
+				errs.addDropped(d.DroppedByCode())
+			}
+		case XError:
+			if !IsNil(e) {
+				errs.Add(e)
+			}
+		case interface{ Unwrap() []error }:
+			errs.join(e.Unwrap()...)
+		default:
+			errs.Add(New(err.Error(), WithInternalExtra(map[string]interface{}{"error": err})))
+		}
+	}
+}
+
+// addDropped adds errors dropped from joined collection to errs dropped errors.
+func (errs *XErrs) addDropped(byCode map[string]int) {
+	for code, n := range byCode {
+		if errs.dropped == nil {
+			errs.dropped = make(map[string]int, len(byCode))
+		}
+
+		errs.dropped[code] += n
+	}
+}
@@ -1,8 +1,9 @@
-// nolint:dupl,funlen
+// nolint:dupl,funlen,goerr113
 package xerrors
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -462,3 +463,258 @@ func TestXErrs_MarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestJoin(t *testing.T) {
+	t.Parallel()
+
+	plainErr := errors.New("plain error")
+	xErr1 := NewXErr("test msg", "test descr", nil, nil)
+	xErr2 := NewXErr("test msg 2", "test descr 2", nil, nil)
+	xErr3 := NewXErr("test msg 3", "test descr 3", nil, nil)
+
+	limited := NewXErrsLimited(1)
+	limited.Add(xErr1, xErr2, xErr3)
+
+	tests := []struct {
+		name string
+		errs []error
+		want *XErrs
+	}{
+		{
+			name: "no errors",
+			errs: nil,
+			want: &XErrs{Errs: []XError{}},
+		},
+		{
+			name: "nil errors",
+			errs: []error{nil, nil},
+			want: &XErrs{Errs: []XError{}},
+		},
+		{
+			name: "typed nil XErr",
+			errs: []error{(*XErr)(nil), &XErrs{Errs: []XError{(*XErr)(nil), xErr1}}},
+			want: &XErrs{Errs: []XError{xErr1}},
+		},
+		{
+			name: "limited XErrs keeps dropped errors",
+			errs: []error{limited, xErr2},
+			want: &XErrs{Errs: []XError{xErr1, xErr2}, dropped: map[string]int{"": 2}},
+		},
+		{
+			name: "XErr and plain error",
+			errs: []error{xErr1, plainErr},
+			want: &XErrs{Errs: []XError{
+				xErr1,
+				NewXErr("plain error", "", nil, map[string]interface{}{"error": plainErr}),
+			}},
+		},
+		{
+			name: "nested XErrs and errors.Join",
+			errs: []error{
+				&XErrs{Errs: []XError{xErr1, xErr2}},
+				errors.Join(xErr3, errors.Join(plainErr)),
+			},
+			want: &XErrs{Errs: []XError{
+				xErr1,
+				xErr2,
+				xErr3,
+				NewXErr("plain error", "", nil, map[string]interface{}{"error": plainErr}),
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Join(tt.errs...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Join() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXErrs_Unwrap(t *testing.T) {
+	t.Parallel()
+
+	plainErr := errors.New("plain error")
+	otherErr := errors.New("other error")
+	xErr := NewXErr("test msg", "test descr", nil, map[string]interface{}{"error": plainErr})
+
+	tests := []struct {
+		name    string
+		xerrs   *XErrs
+		target  error
+		wantIs  bool
+		wantLen int
+	}{
+		{
+			name:    "nil XErrs",
+			xerrs:   nil,
+			target:  plainErr,
+			wantIs:  false,
+			wantLen: 0,
+		},
+		{
+			name:    "XErr in collection",
+			xerrs:   &XErrs{Errs: []XError{NewXErr("test msg 2", "", nil, nil), xErr}},
+			target:  xErr,
+			wantIs:  true,
+			wantLen: 2,
+		},
+		{
+			name:    "cause of XErr in collection",
+			xerrs:   &XErrs{Errs: []XError{xErr}},
+			target:  plainErr,
+			wantIs:  true,
+			wantLen: 1,
+		},
+		{
+			name:    "error not in collection",
+			xerrs:   &XErrs{Errs: []XError{xErr}},
+			target:  otherErr,
+			wantIs:  false,
+			wantLen: 1,
+		},
+		{
+			name:    "typed nil XErr in collection",
+			xerrs:   &XErrs{Errs: []XError{nil, (*XErr)(nil), xErr}},
+			target:  plainErr,
+			wantIs:  true,
+			wantLen: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := len(tt.xerrs.Unwrap()); got != tt.wantLen {
+				t.Errorf("len(Unwrap()) = %v, want %v", got, tt.wantLen)
+			}
+
+			if got := errors.Is(tt.xerrs, tt.target); got != tt.wantIs {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.wantIs)
+			}
+		})
+	}
+}
+
+func TestXErrs_As(t *testing.T) {
+	t.Parallel()
+
+	xErr := NewXErr("test msg", "test descr", nil, nil)
+	joined := errors.Join(errors.New("plain error"), Join(xErr))
+
+	var target *XErr
+	if !errors.As(joined, &target) {
+		t.Fatalf("errors.As() = false, want true")
+	}
+
+	if target != xErr {
+		t.Errorf("errors.As() target = %v, want %v", target, xErr)
+	}
+}
+
+func TestNewXErrsLimited(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		max             int
+		add             []XError
+		wantLen         int
+		wantDropped     int
+		wantDroppedCode map[string]int
+		wantError       string
+		wantJSON        string
+	}{
+		{
+			name: "no limit",
+			max:  0,
+			add: []XError{
+				New("msg 1", WithCode("invalid")),
+				New("msg 2", WithCode("invalid")),
+			},
+			wantLen:         2,
+			wantDropped:     0,
+			wantDroppedCode: nil,
+			wantError:       "msg 1: ; map[];msg 2: ; map[]",
+			wantJSON:        `{"errors":[{"code":"invalid","message":"msg 1"},{"code":"invalid","message":"msg 2"}]}`,
+		},
+		{
+			name: "under limit",
+			max:  2,
+			add: []XError{
+				New("msg 1", WithCode("invalid")),
+			},
+			wantLen:         1,
+			wantDropped:     0,
+			wantDroppedCode: nil,
+			wantError:       "msg 1: ; map[]",
+			wantJSON:        `{"errors":[{"code":"invalid","message":"msg 1"}]}`,
+		},
+		{
+			name: "over limit",
+			max:  1,
+			add: []XError{
+				New("msg 1", WithCode("invalid")),
+				New("msg 2", WithCode("invalid")),
+				New("msg 3", WithCode("not_found")),
+				New("msg 4", WithCode("invalid")),
+			},
+			wantLen:         1,
+			wantDropped:     3,
+			wantDroppedCode: map[string]int{"invalid": 2, "not_found": 1},
+			wantError:       "msg 1: ; map[];and 3 more",
+			wantJSON: `{"errors":[{"code":"invalid","message":"msg 1"},` +
+				`{"code":"truncated","message":"and 3 more","extra":{"dropped":{"invalid":2,"not_found":1}}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			xErrs := NewXErrsLimited(tt.max)
+			for _, xErr := range tt.add {
+				xErrs.Add(xErr)
+			}
+
+			if got := xErrs.Len(); got != tt.wantLen {
+				t.Errorf("Len() = %v, want %v", got, tt.wantLen)
+			}
+
+			if got := xErrs.Truncated(); got != (tt.wantDropped > 0) {
+				t.Errorf("Truncated() = %v, want %v", got, tt.wantDropped > 0)
+			}
+
+			if got := xErrs.Dropped(); got != tt.wantDropped {
+				t.Errorf("Dropped() = %v, want %v", got, tt.wantDropped)
+			}
+
+			if got := xErrs.DroppedByCode(); !reflect.DeepEqual(got, tt.wantDroppedCode) {
+				t.Errorf("DroppedByCode() = %v, want %v", got, tt.wantDroppedCode)
+			}
+
+			if got := xErrs.Error(); got != tt.wantError {
+				t.Errorf("Error() = %v, want %v", got, tt.wantError)
+			}
+
+			got, err := json.Marshal(xErrs)
+			if err != nil {
+				t.Errorf("json.Marshal() error: %v", err)
+			}
+
+			if string(got) != tt.wantJSON {
+				t.Errorf("json.Marshal() = %v, want %v", string(got), tt.wantJSON)
+			}
+		})
+	}
+}
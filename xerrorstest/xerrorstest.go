@@ -0,0 +1,206 @@
+// Package xerrorstest provides utilities to compare XError in tests.
+package xerrorstest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+// Field is a set of compared XError fields.
+type Field int
+
+const (
+	Code Field = 1 << iota
+	Message
+	Description
+	Severity
+	Kind
+	Retry
+	Extra
+	InternalExtra
+	// Cause is compared with errors.Is, it's never compared as a part of InternalExtra.
+	Cause
+
+	// AllFields compares all fields, it's used if Opts.Fields is 0.
+	AllFields = Code | Message | Description | Severity | Kind | Retry | Extra | InternalExtra | Cause
+)
+
+const causeKey = "error"
+
+// defaultIgnoredKeys are internal extra keys that are never compared.
+var defaultIgnoredKeys = []string{ // nolint:gochecknoglobals
+	xerrors.OccurredAtKey,
+	xerrors.HostnameKey,
+	xerrors.ServiceKey,
+	xerrors.ServiceVersionKey,
+	xerrors.FingerprintKey,
+	xerrors.DeadlineKey,
+	xerrors.ElapsedKey,
+}
+
+// DefaultIgnoredKeys returns internal extra keys that are never compared, as they contain timestamps,
+// host specific metadata or depend on stack traces.
+func DefaultIgnoredKeys() []string {
+	return append([]string(nil), defaultIgnoredKeys...)
+}
+
+// Opts configures comparison of errors.
+type Opts struct {
+	// Fields selects compared fields, AllFields by default.
+	Fields Field
+	// IgnoreKeys are extra and internal extra keys that are not compared,
+	// DefaultIgnoredKeys are not compared in internal extra in addition to them.
+	IgnoreKeys []string
+}
+
+// AssertXErr reports test error with readable diff if got doesn't match want and returns whether they match.
+// Stack traces are never compared.
+func AssertXErr(t testing.TB, got, want xerrors.XError, opts Opts) bool {
+	t.Helper()
+
+	if diff := Diff(got, want, opts); diff != "" {
+		t.Errorf("XErr mismatch (-want +got):\n%s", diff)
+
+		return false
+	}
+
+	return true
+}
+
+// Diff returns readable difference between got and want, or empty string if they match.
+func Diff(got, want xerrors.XError, opts Opts) string {
+	gotNil, wantNil := xerrors.IsNil(got), xerrors.IsNil(want)
+
+	switch {
+	case gotNil && wantNil:
+		return ""
+	case gotNil:
+		return fmt.Sprintf("-%v\n+nil\n", want)
+	case wantNil:
+		return fmt.Sprintf("-nil\n+%v\n", got)
+	}
+
+	fields := opts.Fields
+	if fields == 0 {
+		fields = AllFields
+	}
+
+	var b strings.Builder
+
+	diffValue(&b, fields&Code != 0, "Code", xerrors.CodeKey(got), xerrors.CodeKey(want))
+	diffValue(&b, fields&Message != 0, "Message", got.GetMessage(), want.GetMessage())
+	diffValue(&b, fields&Description != 0, "Description", got.GetDescription(), want.GetDescription())
+	diffValue(&b, fields&Severity != 0, "Severity", got.GetSeverity().String(), want.GetSeverity().String())
+	diffValue(&b, fields&Kind != 0, "Kind", got.GetKind(), want.GetKind())
+	diffValue(&b, fields&Retry != 0, "Retryable", retryable(got), retryable(want))
+	diffValue(&b, fields&Retry != 0, "RetryAfter", retryAfter(got).String(), retryAfter(want).String())
+
+	if fields&Extra != 0 {
+		diffMaps(&b, "Extra", got.GetExtra(), want.GetExtra(), keySet(opts.IgnoreKeys))
+	}
+
+	if fields&InternalExtra != 0 {
+		ignored := keySet(defaultIgnoredKeys, opts.IgnoreKeys, []string{causeKey})
+		diffMaps(&b, "InternalExtra", got.GetInternalExtra(), want.GetInternalExtra(), ignored)
+	}
+
+	if fields&Cause != 0 {
+		diffCause(&b, cause(got), cause(want))
+	}
+
+	return b.String()
+}
+
+// keySet returns set of all keys.
+func keySet(keys ...[]string) map[string]bool {
+	set := make(map[string]bool)
+
+	for _, ks := range keys {
+		for _, key := range ks {
+			set[key] = true
+		}
+	}
+
+	return set
+}
+
+func diffValue(b *strings.Builder, compare bool, name string, got, want interface{}) {
+	if compare && got != want {
+		fmt.Fprintf(b, "%s:\n\t-%#v\n\t+%#v\n", name, want, got)
+	}
+}
+
+func diffMaps(b *strings.Builder, name string, got, want map[string]interface{}, ignored map[string]bool) {
+	keys := make([]string, 0, len(got)+len(want))
+
+	for k := range want {
+		keys = append(keys, k)
+	}
+
+	for k := range got {
+		if _, ok := want[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	var lines []string
+
+	for _, k := range keys {
+		if ignored[k] {
+			continue
+		}
+
+		gotV, gotOk := got[k]
+		wantV, wantOk := want[k]
+
+		switch {
+		case !gotOk:
+			lines = append(lines, fmt.Sprintf("\t-%s: %#v", k, wantV))
+		case !wantOk:
+			lines = append(lines, fmt.Sprintf("\t+%s: %#v", k, gotV))
+		case !reflect.DeepEqual(gotV, wantV):
+			lines = append(lines, fmt.Sprintf("\t-%s: %#v", k, wantV), fmt.Sprintf("\t+%s: %#v", k, gotV))
+		}
+	}
+
+	if len(lines) > 0 {
+		fmt.Fprintf(b, "%s:\n%s\n", name, strings.Join(lines, "\n"))
+	}
+}
+
+func diffCause(b *strings.Builder, got, want error) {
+	if (want == nil && got == nil) || (want != nil && errors.Is(got, want)) {
+		return
+	}
+
+	fmt.Fprintf(b, "Cause:\n\t-%v\n\t+%v\n", want, got)
+}
+
+func cause(xErr xerrors.XError) error {
+	err, _ := xErr.GetInternalExtra()[causeKey].(error)
+
+	return err
+}
+
+func retryable(xErr xerrors.XError) bool {
+	r, ok := xErr.(interface{ Retryable() bool })
+
+	return ok && r.Retryable()
+}
+
+func retryAfter(xErr xerrors.XError) time.Duration {
+	if r, ok := xErr.(interface{ RetryAfter() time.Duration }); ok {
+		return r.RetryAfter()
+	}
+
+	return 0
+}
@@ -0,0 +1,174 @@
+// nolint:funlen,goerr113
+package xerrorstest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+type recorder struct {
+	testing.TB
+
+	errs []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+func deadlineCtx(deadline time.Time) context.Context {
+	ctx, cancel := context.WithDeadline(xerrors.ContextWithStart(context.Background(), time.Now()), deadline)
+	cancel()
+
+	return ctx
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("cause")
+	wrapped := fmt.Errorf("wrapped: %w", cause)
+
+	tests := []struct {
+		name string
+		got  xerrors.XError
+		want xerrors.XError
+		opts Opts
+		diff string
+	}{
+		{
+			name: "both nil",
+			got:  (*xerrors.XErr)(nil),
+			want: nil,
+			diff: "",
+		},
+		{
+			name: "got nil",
+			got:  nil,
+			want: xerrors.New("test msg"),
+			diff: "-test msg: ; map[]\n+nil\n",
+		},
+		{
+			name: "equal with stack, timestamps and wrapped cause",
+			got: xerrors.New("test msg",
+				xerrors.WithStack(),
+				xerrors.WithFingerprint(),
+				xerrors.WithInternalExtraField(xerrors.OccurredAtKey, time.Now()),
+				xerrors.WithInternalExtraField("error", wrapped),
+			),
+			want: xerrors.New("test msg", xerrors.WithInternalExtraField("error", cause)),
+			diff: "",
+		},
+		{
+			name: "context deadline and elapsed",
+			got: xerrors.FromContext(
+				deadlineCtx(time.Now().Add(time.Minute)), context.DeadlineExceeded),
+			want: xerrors.FromContext(
+				deadlineCtx(time.Now().Add(time.Hour)), context.DeadlineExceeded),
+			diff: "",
+		},
+		{
+			name: "fields",
+			got: xerrors.New("got msg",
+				xerrors.WithCode("got_code"),
+				xerrors.WithSeverity(xerrors.SeverityWarning),
+				xerrors.WithRetryAfter(time.Second),
+			),
+			want: xerrors.New("want msg", xerrors.WithCode("want_code")),
+			diff: "Code:\n\t-\"want_code\"\n\t+\"got_code\"\n" +
+				"Message:\n\t-\"want msg\"\n\t+\"got msg\"\n" +
+				"Severity:\n\t-\"error\"\n\t+\"warning\"\n" +
+				"Retryable:\n\t-false\n\t+true\n" +
+				"RetryAfter:\n\t-\"0s\"\n\t+\"1s\"\n",
+		},
+		{
+			name: "selected fields",
+			got:  xerrors.New("got msg", xerrors.WithCode("test_code")),
+			want: xerrors.New("want msg", xerrors.WithCode("test_code")),
+			opts: Opts{Fields: Code | Extra},
+			diff: "",
+		},
+		{
+			name: "extra",
+			got: xerrors.New("test msg", xerrors.WithExtra(map[string]interface{}{
+				"same": 1, "changed": "got", "added": true, "ignored": 1,
+			})),
+			want: xerrors.New("test msg", xerrors.WithExtra(map[string]interface{}{
+				"same": 1, "changed": "want", "removed": 2, "ignored": 2,
+			})),
+			opts: Opts{IgnoreKeys: []string{"ignored"}},
+			diff: "Extra:\n\t+added: true\n\t-changed: \"want\"\n\t+changed: \"got\"\n\t-removed: 2\n",
+		},
+		{
+			name: "default ignored keys in extra",
+			got: xerrors.New("test msg", xerrors.WithExtra(map[string]interface{}{
+				xerrors.ServiceKey: "got", xerrors.DeadlineKey: "got",
+			})),
+			want: xerrors.New("test msg", xerrors.WithExtra(map[string]interface{}{
+				xerrors.ServiceKey: "want", xerrors.DeadlineKey: "want",
+			})),
+			diff: "Extra:\n\t-deadline: \"want\"\n\t+deadline: \"got\"\n\t-service: \"want\"\n\t+service: \"got\"\n",
+		},
+		{
+			name: "ignored keys in internal extra",
+			got: xerrors.New("test msg", xerrors.WithInternalExtra(map[string]interface{}{
+				xerrors.ServiceKey: "got", "ignored": 1,
+			})),
+			want: xerrors.New("test msg", xerrors.WithInternalExtra(map[string]interface{}{
+				xerrors.ServiceKey: "want", "ignored": 2,
+			})),
+			opts: Opts{IgnoreKeys: []string{"ignored"}},
+			diff: "",
+		},
+		{
+			name: "cause",
+			got:  xerrors.New("test msg", xerrors.WithInternalExtraField("error", errors.New("other"))),
+			want: xerrors.New("test msg", xerrors.WithInternalExtraField("error", cause)),
+			diff: "Cause:\n\t-cause\n\t+other\n",
+		},
+		{
+			name: "unexpected cause",
+			got:  xerrors.New("test msg", xerrors.WithInternalExtraField("error", cause)),
+			want: xerrors.New("test msg"),
+			diff: "Cause:\n\t-<nil>\n\t+cause\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Diff(tt.got, tt.want, tt.opts); got != tt.diff {
+				t.Errorf("Diff() = %q, want %q", got, tt.diff)
+			}
+		})
+	}
+}
+
+func TestAssertXErr(t *testing.T) {
+	t.Parallel()
+
+	r := &recorder{TB: t}
+
+	if !AssertXErr(r, xerrors.New("test msg"), xerrors.New("test msg"), Opts{}) {
+		t.Errorf("AssertXErr() = false for equal errors")
+	}
+
+	if AssertXErr(r, xerrors.New("got msg"), xerrors.New("want msg"), Opts{}) {
+		t.Errorf("AssertXErr() = true for different errors")
+	}
+
+	if len(r.errs) != 1 || !strings.Contains(r.errs[0], "Message:\n\t-\"want msg\"\n\t+\"got msg\"") {
+		t.Errorf("AssertXErr() reported %q", r.errs)
+	}
+}
@@ -0,0 +1,91 @@
+package xhttp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+// DecodeError decodes error response written by WriteError into *xerrors.XErr.
+// It returns nil if response status code is not an error(< 400).
+// Status code, severity, kind and retry hints are restored from the response status and Retry-After header.
+// The result is created with xerrors.New, so hooks and metrics observe it as any other error.
+// Response body is read, but not closed.
+func DecodeError(resp *http.Response) *xerrors.XErr {
+	if resp == nil || resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	code := resp.StatusCode
+	body := &xerrors.XErr{}
+	msg := http.StatusText(code)
+
+	opts := []xerrors.XErrOpt{
+		xerrors.WithSeverity(SeverityForStatus(code)),
+		xerrors.WithKind(KindForStatus(code)),
+		xerrors.WithRetryable(RetryableStatus(code)),
+	}
+
+	if err := decodeBody(resp.Body, body); err != nil {
+		opts = append(opts, xerrors.WithInternalExtraField("error", err))
+	} else if body.Message != "" {
+		msg = body.Message
+		opts = append(opts,
+			xerrors.WithCode(body.Code),
+			xerrors.WithDescription(body.Description),
+			xerrors.WithExtra(body.Extra),
+		)
+	}
+
+	opts = append(opts, xerrors.WithExtraField("http_code", code))
+
+	if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		opts = append(opts, xerrors.WithRetryAfter(d))
+	}
+
+	return xerrors.New(msg, opts...)
+}
+
+// decodeBody decodes JSON body into the temporary xErr, which is used only as a source of fields.
+func decodeBody(body io.Reader, xErr *xerrors.XErr) error {
+	if body == nil {
+		return nil
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil || len(data) == 0 {
+		return err
+	}
+
+	return json.Unmarshal(data, xErr)
+}
+
+// parseRetryAfter parses Retry-After header value in delay-seconds or HTTP-date format.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+
+		return time.Duration(secs) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	if d := date.Sub(now); d > 0 {
+		return d, true
+	}
+
+	return 0, true
+}
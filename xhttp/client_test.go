@@ -0,0 +1,168 @@
+// nolint:goerr113
+package xhttp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+func TestDecodeError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		xErr xerrors.XError
+		want *xerrors.XErr
+	}{
+		{
+			name: "not found",
+			xErr: NewNotFoundError(errors.New("some error"), xerrors.WithDescription("user not found")),
+			want: xerrors.New("Not Found",
+				xerrors.WithDescription("user not found"),
+				xerrors.WithSeverity(xerrors.SeverityInfo),
+				xerrors.WithKind(xerrors.KindNotFound),
+				xerrors.WithExtra(map[string]interface{}{"http_code": http.StatusNotFound}),
+			),
+		},
+		{
+			name: "too many requests with retry after",
+			xErr: NewTooManyRequestsError(errors.New("some error"), xerrors.WithRetryAfter(time.Minute)),
+			want: xerrors.New("Too Many Requests",
+				xerrors.WithSeverity(xerrors.SeverityWarning),
+				xerrors.WithKind(xerrors.KindTransient),
+				xerrors.WithRetryAfter(time.Minute),
+				xerrors.WithExtra(map[string]interface{}{"http_code": http.StatusTooManyRequests}),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			WriteError(rec, tt.xErr)
+
+			if got := DecodeError(rec.Result()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeError() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeError_NotXErr(t *testing.T) {
+	t.Parallel()
+
+	ok := &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+	if got := DecodeError(ok); got != nil {
+		t.Errorf("DecodeError() = %v, want nil", got)
+	}
+
+	resp := &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{"Retry-After": []string{"120"}},
+		Body:       http.NoBody,
+	}
+
+	want := xerrors.New("Service Unavailable",
+		xerrors.WithSeverity(xerrors.SeverityError),
+		xerrors.WithKind(xerrors.KindTransient),
+		xerrors.WithRetryAfter(2*time.Minute),
+		xerrors.WithExtra(map[string]interface{}{"http_code": http.StatusServiceUnavailable}),
+	)
+
+	if got := DecodeError(resp); !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodeError() = %v, want %v", got, want)
+	}
+
+	resp = &http.Response{
+		StatusCode: http.StatusBadGateway,
+		Body:       io.NopCloser(strings.NewReader("<html>bad gateway</html>")),
+	}
+
+	got := DecodeError(resp)
+	if got.GetMessage() != "Bad Gateway" || got.Unwrap() == nil {
+		t.Errorf("DecodeError() = %v, want Bad Gateway with cause", got)
+	}
+}
+
+func TestDecodeError_Hooks(t *testing.T) {
+	t.Parallel()
+
+	const code = "decode_error_hooks_test"
+
+	var (
+		mu     sync.Mutex
+		called []string
+	)
+
+	t.Cleanup(xerrors.OnNew(func(xErr *xerrors.XErr) {
+		if xErr.GetCode() != code {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		called = append(called, xErr.GetMessage())
+	}))
+
+	rec := httptest.NewRecorder()
+	WriteError(rec, NewConflictError(errors.New("some error"), xerrors.WithCode(code)))
+
+	if got := DecodeError(rec.Result()); got.GetCode() != code {
+		t.Fatalf("DecodeError().GetCode() = %v, want %v", got.GetCode(), code)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// the first call is for the written error, the second one for the decoded error.
+	if want := []string{"Conflict", "Conflict"}; !reflect.DeepEqual(called, want) {
+		t.Errorf("OnNew hook called for %v, want %v", called, want)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2021, 9, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{name: "empty", value: "", want: 0, wantOk: false},
+		{name: "seconds", value: "30", want: 30 * time.Second, wantOk: true},
+		{name: "negative seconds", value: "-1", want: 0, wantOk: false},
+		{name: "http date", value: "Sun, 19 Sep 2021 12:01:00 GMT", want: time.Minute, wantOk: true},
+		{name: "past http date", value: "Sun, 19 Sep 2021 11:00:00 GMT", want: 0, wantOk: true},
+		{name: "invalid", value: "soon", want: 0, wantOk: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := parseRetryAfter(tt.value, now)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("parseRetryAfter() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
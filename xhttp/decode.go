@@ -0,0 +1,108 @@
+package xhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+// DecodeOpts configures DecodeJSON.
+type DecodeOpts struct {
+	// MaxBytes limits request body size, non-positive value means no limit.
+	MaxBytes int64
+	// DisallowUnknownFields makes fields that are not present in dst an error.
+	DisallowUnknownFields bool
+	// AllowEmpty makes empty body valid, dst is left untouched in that case.
+	AllowEmpty bool
+}
+
+// DecodeJSON decodes single JSON value from request body into dst.
+// Decoding failures are mapped into XErr with safe public details in extra:
+//   - empty body, malformed JSON and trailing data into BadRequest(400), syntax errors include "offset";
+//   - type mismatches and unknown fields into UnprocessableEntity(422) with "field" and "offset",
+//     expected Go type of type mismatches is kept in internal extra under "expected_type";
+//   - body exceeding MaxBytes into RequestEntityTooLarge(413) with "limit".
+//
+// Decoding error itself is kept in description and as a cause.
+func DecodeJSON(r *http.Request, dst interface{}, opts DecodeOpts) *xerrors.XErr {
+	body := io.Reader(http.NoBody)
+	if r.Body != nil {
+		body = r.Body
+	}
+
+	if opts.MaxBytes > 0 {
+		body = http.MaxBytesReader(nil, io.NopCloser(body), opts.MaxBytes)
+	}
+
+	dec := json.NewDecoder(body)
+	if opts.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+
+	if err := dec.Decode(dst); err != nil {
+		if errors.Is(err, io.EOF) && opts.AllowEmpty {
+			return nil
+		}
+
+		return decodeError(err)
+	}
+
+	var rest json.RawMessage
+	if err := dec.Decode(&rest); !errors.Is(err, io.EOF) {
+		if err == nil {
+			err = errors.New("request body must contain a single JSON value") // nolint:goerr113
+		}
+
+		return decodeError(err)
+	}
+
+	return nil
+}
+
+// nolint:cyclop
+func decodeError(err error) *xerrors.XErr {
+	var (
+		syntaxErr    *json.SyntaxError
+		typeErr      *json.UnmarshalTypeError
+		maxBytesErr  *http.MaxBytesError
+		unmarshalErr *json.InvalidUnmarshalError
+	)
+
+	switch {
+	case errors.Is(err, io.EOF):
+		return newDecodeError(err, http.StatusBadRequest, "request body is empty", nil)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return newDecodeError(err, http.StatusBadRequest, "request body contains malformed JSON", nil)
+	case errors.As(err, &syntaxErr):
+		return newDecodeError(err, http.StatusBadRequest, err.Error(),
+			map[string]interface{}{"offset": syntaxErr.Offset})
+	case errors.As(err, &typeErr):
+		return newDecodeError(err, http.StatusUnprocessableEntity, err.Error(), map[string]interface{}{
+			"field":  typeErr.Field,
+			"offset": typeErr.Offset,
+		}, xerrors.WithInternalExtraField("expected_type", typeErr.Type.String()))
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+
+		return newDecodeError(err, http.StatusUnprocessableEntity, err.Error(), map[string]interface{}{"field": field})
+	case errors.As(err, &maxBytesErr):
+		return newDecodeError(err, http.StatusRequestEntityTooLarge, "request body is too large",
+			map[string]interface{}{"limit": maxBytesErr.Limit})
+	case errors.As(err, &unmarshalErr):
+		return NewInternalServerError(err, xerrors.WithDescription(err.Error()))
+	default:
+		return newDecodeError(err, http.StatusBadRequest, err.Error(), nil)
+	}
+}
+
+func newDecodeError(
+	err error, code int, descr string, extra map[string]interface{}, opts ...xerrors.XErrOpt,
+) *xerrors.XErr {
+	opts = append([]xerrors.XErrOpt{xerrors.WithDescription(descr), xerrors.MergeExtra(extra)}, opts...)
+
+	return NewError(err, http.StatusText(code), code, opts...)
+}
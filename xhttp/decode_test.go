@@ -0,0 +1,188 @@
+// nolint:funlen
+package xhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+type decodeUser struct {
+	Name    string `json:"name"`
+	Age     int    `json:"age"`
+	Address struct {
+		Zip int `json:"zip"`
+	} `json:"address"`
+}
+
+func TestDecodeJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		body      string
+		opts      DecodeOpts
+		wantNil   bool
+		wantCode  int
+		wantDescr string
+		wantExtra map[string]interface{}
+		// wantInternal holds expected internal extra values, other keys are not compared.
+		wantInternal map[string]interface{}
+	}{
+		{
+			name:    "valid body",
+			body:    `{"name":"John","age":42}`,
+			wantNil: true,
+		},
+		{
+			name:      "empty body",
+			body:      "",
+			wantCode:  http.StatusBadRequest,
+			wantExtra: map[string]interface{}{"http_code": http.StatusBadRequest},
+		},
+		{
+			name:    "empty body allowed",
+			body:    "",
+			opts:    DecodeOpts{AllowEmpty: true},
+			wantNil: true,
+		},
+		{
+			name:      "syntax error",
+			body:      `{"name":"John",}`,
+			wantCode:  http.StatusBadRequest,
+			wantExtra: map[string]interface{}{"http_code": http.StatusBadRequest, "offset": int64(16)},
+		},
+		{
+			name:      "unexpected EOF",
+			body:      `{"name":"John"`,
+			wantCode:  http.StatusBadRequest,
+			wantExtra: map[string]interface{}{"http_code": http.StatusBadRequest},
+		},
+		{
+			name:     "type mismatch",
+			body:     `{"name":"John","address":{"zip":"01001"}}`,
+			wantCode: http.StatusUnprocessableEntity,
+			wantExtra: map[string]interface{}{
+				"http_code": http.StatusUnprocessableEntity,
+				"field":     "address.zip",
+				"offset":    int64(39),
+			},
+			wantInternal: map[string]interface{}{"expected_type": "int"},
+		},
+		{
+			name:    "unknown field allowed",
+			body:    `{"name":"John","email":"john@example.com"}`,
+			wantNil: true,
+		},
+		{
+			name:      "unknown field",
+			body:      `{"name":"John","email":"john@example.com"}`,
+			opts:      DecodeOpts{DisallowUnknownFields: true},
+			wantCode:  http.StatusUnprocessableEntity,
+			wantExtra: map[string]interface{}{"http_code": http.StatusUnprocessableEntity, "field": "email"},
+		},
+		{
+			name:      "trailing data",
+			body:      `{"name":"John"}{"name":"Jane"}`,
+			wantCode:  http.StatusBadRequest,
+			wantDescr: "request body must contain a single JSON value",
+			wantExtra: map[string]interface{}{"http_code": http.StatusBadRequest},
+		},
+		{
+			name:      "trailing object with unknown field",
+			body:      `{"name":"John"}{"email":"john@example.com"}`,
+			opts:      DecodeOpts{DisallowUnknownFields: true},
+			wantCode:  http.StatusBadRequest,
+			wantDescr: "request body must contain a single JSON value",
+			wantExtra: map[string]interface{}{"http_code": http.StatusBadRequest},
+		},
+		{
+			name:      "trailing number",
+			body:      `{"name":"John"} 5`,
+			wantCode:  http.StatusBadRequest,
+			wantDescr: "request body must contain a single JSON value",
+			wantExtra: map[string]interface{}{"http_code": http.StatusBadRequest},
+		},
+		{
+			name:    "trailing whitespace",
+			body:    "{\"name\":\"John\"}\n\t ",
+			wantNil: true,
+		},
+		{
+			name:      "too large",
+			body:      `{"name":"` + strings.Repeat("a", 100) + `"}`,
+			opts:      DecodeOpts{MaxBytes: 32},
+			wantCode:  http.StatusRequestEntityTooLarge,
+			wantExtra: map[string]interface{}{"http_code": http.StatusRequestEntityTooLarge, "limit": int64(32)},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(tt.body))
+
+			var dst decodeUser
+
+			got := DecodeJSON(r, &dst, tt.opts)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("DecodeJSON() = %v, want nil", got)
+				}
+
+				return
+			}
+
+			if got == nil {
+				t.Fatalf("DecodeJSON() = nil, want error")
+			}
+
+			if code := StatusCode(got); code != tt.wantCode {
+				t.Errorf("DecodeJSON() status code = %v, want %v", code, tt.wantCode)
+			}
+
+			if !reflect.DeepEqual(got.GetExtra(), tt.wantExtra) {
+				t.Errorf("DecodeJSON() extra = %v, want %v", got.GetExtra(), tt.wantExtra)
+			}
+
+			if got.Unwrap() == nil {
+				t.Errorf("DecodeJSON() has no cause")
+			}
+
+			checkDecodeDetails(t, got, tt.wantDescr, tt.wantInternal)
+		})
+	}
+}
+
+// checkDecodeDetails checks description if wantDescr is not empty and internal extra values listed in wantInternal.
+func checkDecodeDetails(t *testing.T, got *xerrors.XErr, wantDescr string, wantInternal map[string]interface{}) {
+	t.Helper()
+
+	if wantDescr != "" && got.GetDescription() != wantDescr {
+		t.Errorf("DecodeJSON() description = %v, want %v", got.GetDescription(), wantDescr)
+	}
+
+	for key, want := range wantInternal {
+		if v := got.GetInternalExtra()[key]; v != want {
+			t.Errorf("DecodeJSON() internal extra[%s] = %v, want %v", key, v, want)
+		}
+	}
+}
+
+func TestDecodeJSON_InvalidDst(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"name":"John"}`))
+
+	var dst decodeUser
+	if got := DecodeJSON(r, dst, DecodeOpts{}); StatusCode(got) != http.StatusInternalServerError {
+		t.Errorf("DecodeJSON() = %v, want internal server error", got)
+	}
+}
@@ -0,0 +1,31 @@
+package xhttp
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+// HandlerFunc is an HTTP handler that returns XError instead of writing it to the response.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) xerrors.XError
+
+// ServeHTTP calls h and writes returned error with WriteError.
+// Errors caused by request context cancellation or deadline are mapped with xerrors.FromContext,
+// request start time is stored in the context unless it's already there.
+func (h HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, ok := xerrors.StartFromContext(r.Context()); !ok {
+		r = r.WithContext(xerrors.ContextWithStart(r.Context(), time.Now()))
+	}
+
+	xErr := h(w, r)
+	if xerrors.IsNil(xErr) {
+		return
+	}
+
+	if ctxErr := xerrors.FromContext(r.Context(), xErr); ctxErr != nil {
+		xErr = ctxErr
+	}
+
+	WriteError(w, xErr)
+}
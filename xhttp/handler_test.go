@@ -0,0 +1,82 @@
+// nolint:goerr113
+package xhttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+func TestHandlerFunc_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		handler  HandlerFunc
+		wantCode int
+	}{
+		{
+			name: "no error",
+			handler: func(w http.ResponseWriter, r *http.Request) xerrors.XError {
+				w.WriteHeader(http.StatusNoContent)
+
+				return nil
+			},
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name: "nil XErr",
+			handler: func(w http.ResponseWriter, r *http.Request) xerrors.XError {
+				w.WriteHeader(http.StatusNoContent)
+
+				return NewNotFoundError(nil)
+			},
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name: "error",
+			handler: func(w http.ResponseWriter, r *http.Request) xerrors.XError {
+				return NewNotFoundError(errors.New("some error"))
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "deadline exceeded",
+			handler: func(w http.ResponseWriter, r *http.Request) xerrors.XError {
+				if _, ok := xerrors.StartFromContext(r.Context()); !ok {
+					return NewBadRequestError(errors.New("no start time"))
+				}
+
+				return NewInternalServerError(fmt.Errorf("query users: %w", context.DeadlineExceeded))
+			},
+			wantCode: http.StatusGatewayTimeout,
+		},
+		{
+			name: "canceled",
+			handler: func(w http.ResponseWriter, r *http.Request) xerrors.XError {
+				return NewInternalServerError(context.Canceled)
+			},
+			wantCode: xerrors.StatusClientClosedRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", nil))
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("ServeHTTP() code = %v, want %v", rec.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package xhttp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+// Headers read by ContextMiddleware.
+const (
+	HeaderRequestID = "X-Request-Id"
+	HeaderTraceID   = "X-Trace-Id"
+	HeaderSpanID    = "X-Span-Id"
+)
+
+const requestIDBytes = 16
+
+// ContextMiddleware seeds request context with request ID, trace ID and span ID from request headers
+// and request start time, so errors created with xerrors.WithContext(r.Context()) carry them.
+// W3C traceparent and tracestate headers take precedence over X-Trace-Id and X-Span-Id.
+// Request ID is generated if it's missing in the request and is echoed in the response header.
+func ContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := xerrors.ContextWithStart(r.Context(), time.Now())
+
+		requestID := r.Header.Get(HeaderRequestID)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+
+		if requestID != "" {
+			ctx = xerrors.ContextWithRequestID(ctx, requestID)
+			w.Header().Set(HeaderRequestID, requestID)
+		}
+
+		next.ServeHTTP(w, r.WithContext(contextWithTrace(ctx, r.Header)))
+	})
+}
+
+func contextWithTrace(ctx context.Context, h http.Header) context.Context {
+	if tp, ok := ParseTraceparent(h.Get(HeaderTraceparent)); ok {
+		ctx = xerrors.ContextWithTraceID(ctx, tp.TraceID)
+		ctx = xerrors.ContextWithSpanID(ctx, tp.ParentID)
+
+		if state := h.Get(HeaderTracestate); validTracestate(state) {
+			ctx = xerrors.ContextWithTraceState(ctx, state)
+		}
+
+		return ctx
+	}
+
+	if traceID := h.Get(HeaderTraceID); traceID != "" {
+		ctx = xerrors.ContextWithTraceID(ctx, traceID)
+	}
+
+	if spanID := h.Get(HeaderSpanID); spanID != "" {
+		ctx = xerrors.ContextWithSpanID(ctx, spanID)
+	}
+
+	return ctx
+}
+
+func newRequestID() string {
+	b := make([]byte, requestIDBytes)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,79 @@
+// nolint:goerr113
+package xhttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+func TestContextMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		headers      map[string]string
+		wantIntExtra map[string]interface{}
+	}{
+		{
+			name:         "generated request ID",
+			headers:      nil,
+			wantIntExtra: map[string]interface{}{"error": errors.New("user not found")},
+		},
+		{
+			name: "IDs from headers",
+			headers: map[string]string{
+				HeaderRequestID: "req-1",
+				HeaderTraceID:   "trace-1",
+				HeaderSpanID:    "span-1",
+			},
+			wantIntExtra: map[string]interface{}{
+				"error":            errors.New("user not found"),
+				xerrors.TraceIDKey: "trace-1",
+				xerrors.SpanIDKey:  "span-1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var xErr *xerrors.XErr
+
+			handler := ContextMiddleware(HandlerFunc(func(w http.ResponseWriter, r *http.Request) xerrors.XError {
+				xErr = NewNotFoundError(errors.New("user not found"), xerrors.WithContext(r.Context()))
+
+				return xErr
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, r)
+
+			requestID := rec.Header().Get(HeaderRequestID)
+			if requestID == "" || (tt.headers != nil && requestID != tt.headers[HeaderRequestID]) {
+				t.Errorf("%s header = %q", HeaderRequestID, requestID)
+			}
+
+			wantExtra := map[string]interface{}{"http_code": http.StatusNotFound, xerrors.RequestIDKey: requestID}
+			if !reflect.DeepEqual(xErr.GetExtra(), wantExtra) {
+				t.Errorf("GetExtra() = %v, want %v", xErr.GetExtra(), wantExtra)
+			}
+
+			if !reflect.DeepEqual(xErr.GetInternalExtra(), tt.wantIntExtra) {
+				t.Errorf("GetInternalExtra() = %v, want %v", xErr.GetInternalExtra(), tt.wantIntExtra)
+			}
+		})
+	}
+}
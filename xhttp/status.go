@@ -0,0 +1,169 @@
+package xhttp
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+// StatusCode returns HTTP status code stored in extra under the "http_code" key
+// or 0 if xErr has no status code.
+// Status codes decoded from JSON (float64 or json.Number) are supported as well.
+func StatusCode(xErr xerrors.XError) int {
+	code, _ := xerrors.ExtraValue[int](xErr, "http_code")
+
+	return code
+}
+
+// StatusKey returns HTTP status code as a string, it can be used as a key for xerrors.XErrs.GroupBy.
+func StatusKey(xErr xerrors.XError) string {
+	return strconv.Itoa(StatusCode(xErr))
+}
+
+// ByStatus orders errors from the highest HTTP status code to the lowest, it can be used with xerrors.XErrs.Sort.
+func ByStatus(a, b xerrors.XError) bool {
+	return StatusCode(a) > StatusCode(b)
+}
+
+// StatusStrategy resolves single HTTP status code for multiple errors.
+// Errors without status code are treated as http.StatusInternalServerError.
+type StatusStrategy func(xErrs []xerrors.XError) int
+
+// DefaultStatusStrategy is used by StatusCodeForErrors and WriteErrors when strategy is nil.
+var DefaultStatusStrategy StatusStrategy = HighestSeverity // nolint:gochecknoglobals
+
+// StatusCodeForErrors returns HTTP status code to respond with for errors collection resolved by strategy,
+// DefaultStatusStrategy is used if strategy is nil. It returns http.StatusOK for empty collection.
+func StatusCodeForErrors(xErrs *xerrors.XErrs, strategy StatusStrategy) int {
+	if xErrs.Len() == 0 {
+		return http.StatusOK
+	}
+
+	if strategy == nil {
+		strategy = DefaultStatusStrategy
+	}
+
+	return strategy(xErrs.GetErrors())
+}
+
+// HighestSeverity returns status code of the error with the highest severity,
+// the highest status code wins a tie. Nil errors are skipped,
+// http.StatusOK is returned if there are no other errors.
+func HighestSeverity(xErrs []xerrors.XError) int {
+	var (
+		highest xerrors.Severity
+		status  = http.StatusOK
+	)
+
+	for _, xErr := range xErrs {
+		if xerrors.IsNil(xErr) {
+			continue
+		}
+
+		severity, code := xErr.GetSeverity(), statusOrDefault(xErr)
+		if severity > highest || (severity == highest && code > status) {
+			highest, status = severity, code
+		}
+	}
+
+	return status
+}
+
+// MostFrequent returns the most frequent status code, the highest one wins a tie.
+func MostFrequent(xErrs []xerrors.XError) int {
+	counts := make(map[int]int)
+	frequent := 0
+
+	for _, xErr := range xErrs {
+		code := statusOrDefault(xErr)
+		counts[code]++
+
+		if counts[code] > counts[frequent] || (counts[code] == counts[frequent] && code > frequent) {
+			frequent = code
+		}
+	}
+
+	return frequent
+}
+
+// MultiStatus returns http.StatusMultiStatus(207) if errors have different status codes,
+// otherwise their common status code.
+func MultiStatus(xErrs []xerrors.XError) int {
+	status := 0
+
+	for i, xErr := range xErrs {
+		code := statusOrDefault(xErr)
+		if i > 0 && code != status {
+			return http.StatusMultiStatus
+		}
+
+		status = code
+	}
+
+	return status
+}
+
+// ServerErrorWins returns the most frequent server error(5xx) status code if there is any,
+// otherwise the most frequent status code.
+func ServerErrorWins(xErrs []xerrors.XError) int {
+	serverErrs := make([]xerrors.XError, 0, len(xErrs))
+
+	for _, xErr := range xErrs {
+		if statusOrDefault(xErr) >= http.StatusInternalServerError {
+			serverErrs = append(serverErrs, xErr)
+		}
+	}
+
+	if len(serverErrs) > 0 {
+		return MostFrequent(serverErrs)
+	}
+
+	return MostFrequent(xErrs)
+}
+
+// SeverityForStatus returns default error severity for HTTP status code.
+func SeverityForStatus(code int) xerrors.Severity {
+	switch {
+	case code == http.StatusNotFound:
+		return xerrors.SeverityInfo
+	case code >= http.StatusBadRequest && code < http.StatusInternalServerError:
+		return xerrors.SeverityWarning
+	default:
+		return xerrors.SeverityError
+	}
+}
+
+// KindForStatus returns default error kind for HTTP status code.
+func KindForStatus(code int) xerrors.Kind {
+	switch code {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return xerrors.KindAuth
+	case http.StatusNotFound:
+		return xerrors.KindNotFound
+	case http.StatusConflict:
+		return xerrors.KindConflict
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return xerrors.KindTransient
+	}
+
+	if code >= http.StatusBadRequest && code < http.StatusInternalServerError {
+		return xerrors.KindValidation
+	}
+
+	return xerrors.KindInternal
+}
+
+// RetryableStatus reports whether request failed with HTTP status code can be retried.
+func RetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable
+}
+
+func statusOrDefault(xErr xerrors.XError) int {
+	if code := StatusCode(xErr); code != 0 {
+		return code
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,250 @@
+// nolint:goerr113
+package xhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+func TestStatusCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		xErr xerrors.XError
+		want int
+	}{
+		{
+			name: "nil error",
+			xErr: nil,
+			want: 0,
+		},
+		{
+			name: "without status code",
+			xErr: xerrors.New("some error"),
+			want: 0,
+		},
+		{
+			name: "int status code",
+			xErr: NewNotFoundError(errors.New("some error")),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "float64 status code",
+			xErr: xerrors.New("some error", xerrors.WithExtra(map[string]interface{}{"http_code": float64(409)})),
+			want: http.StatusConflict,
+		},
+		{
+			name: "json.Number status code",
+			xErr: xerrors.New("some error", xerrors.WithExtra(map[string]interface{}{"http_code": json.Number("503")})),
+			want: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := StatusCode(tt.xErr); got != tt.want {
+				t.Errorf("StatusCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestByStatus(t *testing.T) {
+	t.Parallel()
+
+	notFound := NewNotFoundError(errors.New("some error"))
+	internal := NewInternalServerError(errors.New("some error"))
+	badRequest := NewBadRequestError(errors.New("some error"))
+
+	xErrs := &xerrors.XErrs{Errs: []xerrors.XError{notFound, internal, badRequest}}
+	xErrs.Sort(ByStatus)
+
+	want := []xerrors.XError{internal, notFound, badRequest}
+	if !reflect.DeepEqual(xErrs.Errs, want) {
+		t.Errorf("Sort(ByStatus) = %v, want %v", xErrs.Errs, want)
+	}
+
+	groups := xErrs.GroupBy(StatusKey)
+	if got := groups["404"].Len(); got != 1 {
+		t.Errorf("GroupBy(StatusKey)[404].Len() = %v, want 1", got)
+	}
+}
+
+func TestStatusCodeForErrors(t *testing.T) {
+	t.Parallel()
+
+	notFound := NewNotFoundError(errors.New("some error"))
+	badRequest := NewBadRequestError(errors.New("some error"))
+	internal := NewInternalServerError(errors.New("some error"))
+	noCode := xerrors.New("some error")
+
+	mixed := &xerrors.XErrs{Errs: []xerrors.XError{notFound, badRequest, notFound, internal}}
+	clientErrs := &xerrors.XErrs{Errs: []xerrors.XError{notFound, badRequest, notFound}}
+
+	tests := []struct {
+		name     string
+		xErrs    *xerrors.XErrs
+		strategy StatusStrategy
+		want     int
+	}{
+		{
+			name:     "nil XErrs",
+			xErrs:    nil,
+			strategy: nil,
+			want:     http.StatusOK,
+		},
+		{
+			name:     "default strategy",
+			xErrs:    mixed,
+			strategy: nil,
+			want:     http.StatusInternalServerError,
+		},
+		{
+			name:     "error without status code",
+			xErrs:    &xerrors.XErrs{Errs: []xerrors.XError{badRequest, noCode}},
+			strategy: HighestSeverity,
+			want:     http.StatusInternalServerError,
+		},
+		{
+			name:     "nil error with default strategy",
+			xErrs:    &xerrors.XErrs{Errs: []xerrors.XError{nil, notFound}},
+			strategy: nil,
+			want:     http.StatusNotFound,
+		},
+		{
+			name:     "most frequent",
+			xErrs:    mixed,
+			strategy: MostFrequent,
+			want:     http.StatusNotFound,
+		},
+		{
+			name:     "most frequent tie",
+			xErrs:    &xerrors.XErrs{Errs: []xerrors.XError{badRequest, notFound}},
+			strategy: MostFrequent,
+			want:     http.StatusNotFound,
+		},
+		{
+			name:     "multi status mixed",
+			xErrs:    mixed,
+			strategy: MultiStatus,
+			want:     http.StatusMultiStatus,
+		},
+		{
+			name:     "multi status same",
+			xErrs:    &xerrors.XErrs{Errs: []xerrors.XError{notFound, notFound}},
+			strategy: MultiStatus,
+			want:     http.StatusNotFound,
+		},
+		{
+			name:     "server error wins",
+			xErrs:    mixed,
+			strategy: ServerErrorWins,
+			want:     http.StatusInternalServerError,
+		},
+		{
+			name:     "server error wins without server errors",
+			xErrs:    clientErrs,
+			strategy: ServerErrorWins,
+			want:     http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := StatusCodeForErrors(tt.xErrs, tt.strategy); got != tt.want {
+				t.Errorf("StatusCodeForErrors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeverityForStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		code         int
+		wantSeverity xerrors.Severity
+		wantKind     xerrors.Kind
+	}{
+		{code: http.StatusBadRequest, wantSeverity: xerrors.SeverityWarning, wantKind: xerrors.KindValidation},
+		{code: http.StatusUnauthorized, wantSeverity: xerrors.SeverityWarning, wantKind: xerrors.KindAuth},
+		{code: http.StatusForbidden, wantSeverity: xerrors.SeverityWarning, wantKind: xerrors.KindAuth},
+		{code: http.StatusNotFound, wantSeverity: xerrors.SeverityInfo, wantKind: xerrors.KindNotFound},
+		{code: http.StatusConflict, wantSeverity: xerrors.SeverityWarning, wantKind: xerrors.KindConflict},
+		{code: http.StatusUnprocessableEntity, wantSeverity: xerrors.SeverityWarning, wantKind: xerrors.KindValidation},
+		{code: http.StatusTooManyRequests, wantSeverity: xerrors.SeverityWarning, wantKind: xerrors.KindTransient},
+		{code: http.StatusInternalServerError, wantSeverity: xerrors.SeverityError, wantKind: xerrors.KindInternal},
+		{code: http.StatusServiceUnavailable, wantSeverity: xerrors.SeverityError, wantKind: xerrors.KindTransient},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(http.StatusText(tt.code), func(t *testing.T) {
+			t.Parallel()
+
+			if got := SeverityForStatus(tt.code); got != tt.wantSeverity {
+				t.Errorf("SeverityForStatus() = %v, want %v", got, tt.wantSeverity)
+			}
+
+			if got := KindForStatus(tt.code); got != tt.wantKind {
+				t.Errorf("KindForStatus() = %v, want %v", got, tt.wantKind)
+			}
+		})
+	}
+}
+
+func TestHighestSeverity(t *testing.T) {
+	t.Parallel()
+
+	critical := NewBadRequestError(errors.New("some error"), xerrors.WithSeverity(xerrors.SeverityCritical))
+	internal := NewInternalServerError(errors.New("some error"))
+
+	tests := []struct {
+		name  string
+		xErrs []xerrors.XError
+		want  int
+	}{
+		{
+			name:  "highest severity wins",
+			xErrs: []xerrors.XError{internal, critical},
+			want:  http.StatusBadRequest,
+		},
+		{
+			name:  "nil errors are skipped",
+			xErrs: []xerrors.XError{nil, internal, (*xerrors.XErr)(nil)},
+			want:  http.StatusInternalServerError,
+		},
+		{
+			name:  "only nil errors",
+			xErrs: []xerrors.XError{nil, (*xerrors.XErr)(nil)},
+			want:  http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := HighestSeverity(tt.xErrs); got != tt.want {
+				t.Errorf("HighestSeverity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
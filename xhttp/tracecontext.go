@@ -0,0 +1,92 @@
+package xhttp
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// W3C Trace Context headers, see https://www.w3.org/TR/trace-context/.
+const (
+	HeaderTraceparent = "Traceparent"
+	HeaderTracestate  = "Tracestate"
+)
+
+const (
+	traceIDLen     = 32
+	parentIDLen    = 16
+	maxTracestate  = 512
+	traceparentLen = 55
+)
+
+// Traceparent represents parsed W3C traceparent header.
+type Traceparent struct {
+	Version  string
+	TraceID  string
+	ParentID string
+	Flags    string
+}
+
+// ParseTraceparent parses W3C traceparent header value, e.g.
+// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+// It reports false if the value is invalid, including all-zero trace and parent IDs.
+func ParseTraceparent(value string) (Traceparent, bool) {
+	value = strings.TrimSpace(value)
+
+	parts := strings.Split(value, "-")
+	if len(parts) < 4 { // nolint:gomnd
+		return Traceparent{}, false
+	}
+
+	tp := Traceparent{Version: parts[0], TraceID: parts[1], ParentID: parts[2], Flags: parts[3]}
+	if !tp.valid(len(value)) {
+		return Traceparent{}, false
+	}
+
+	return tp, true
+}
+
+// valid reports whether traceparent parsed from the header value of length n is valid.
+func (tp Traceparent) valid(n int) bool {
+	versionOk := isLowerHex(tp.Version, 2) && tp.Version != "ff" && // nolint:gomnd
+		(tp.Version != "00" || n == traceparentLen)
+
+	return versionOk &&
+		isLowerHex(tp.TraceID, traceIDLen) && !isZeros(tp.TraceID) &&
+		isLowerHex(tp.ParentID, parentIDLen) && !isZeros(tp.ParentID) &&
+		isLowerHex(tp.Flags, 2) // nolint:gomnd
+}
+
+// String formats traceparent as a header value.
+func (tp Traceparent) String() string {
+	return tp.Version + "-" + tp.TraceID + "-" + tp.ParentID + "-" + tp.Flags
+}
+
+// validTracestate reports whether tracestate header value can be propagated,
+// only its length and characters are checked.
+func validTracestate(value string) bool {
+	if value == "" || len(value) > maxTracestate {
+		return false
+	}
+
+	for _, c := range value {
+		if c < 0x20 || c > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isLowerHex(s string, n int) bool {
+	if len(s) != n || strings.ToLower(s) != s {
+		return false
+	}
+
+	_, err := hex.DecodeString(s)
+
+	return err == nil
+}
+
+func isZeros(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
@@ -0,0 +1,127 @@
+// nolint:goerr113
+package xhttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestParseTraceparent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		value  string
+		want   Traceparent
+		wantOk bool
+	}{
+		{
+			name:  "valid",
+			value: testTraceparent,
+			want: Traceparent{
+				Version:  "00",
+				TraceID:  "4bf92f3577b34da6a3ce929d0e0e4736",
+				ParentID: "00f067aa0ba902b7",
+				Flags:    "01",
+			},
+			wantOk: true,
+		},
+		{
+			name:  "future version with extra fields",
+			value: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future",
+			want: Traceparent{
+				Version:  "01",
+				TraceID:  "4bf92f3577b34da6a3ce929d0e0e4736",
+				ParentID: "00f067aa0ba902b7",
+				Flags:    "01",
+			},
+			wantOk: true,
+		},
+		{name: "empty", value: "", wantOk: false},
+		{name: "version 00 with extra fields", value: testTraceparent + "-extra", wantOk: false},
+		{name: "invalid version", value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", wantOk: false},
+		{name: "uppercase", value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", wantOk: false},
+		{name: "zero trace ID", value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", wantOk: false},
+		{name: "zero parent ID", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", wantOk: false},
+		{name: "short trace ID", value: "00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01", wantOk: false},
+		{name: "invalid flags", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-zz", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := ParseTraceparent(tt.value)
+			if ok != tt.wantOk || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTraceparent() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+
+			if ok && tt.want.Version == "00" && got.String() != tt.value {
+				t.Errorf("String() = %v, want %v", got.String(), tt.value)
+			}
+		})
+	}
+}
+
+func TestContextMiddleware_Traceparent(t *testing.T) {
+	t.Parallel()
+
+	handler := ContextMiddleware(HandlerFunc(func(w http.ResponseWriter, r *http.Request) xerrors.XError {
+		return NewNotFoundError(errors.New("user not found"), xerrors.WithContext(r.Context()))
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	r.Header.Set(HeaderRequestID, "req-1")
+	r.Header.Set(HeaderTraceparent, testTraceparent)
+	r.Header.Set(HeaderTracestate, "congo=t61rcWkgMzE")
+	r.Header.Set(HeaderTraceID, "ignored")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+
+	if got := rec.Header().Get(HeaderTraceID); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("%s header = %v, want 4bf92f3577b34da6a3ce929d0e0e4736", HeaderTraceID, got)
+	}
+
+	want := `{"message":"Not Found","extra":` +
+		`{"http_code":404,"request_id":"req-1","trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"}}`
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body = %v, want %v", got, want)
+	}
+}
+
+func TestWriteErrors_TraceID(t *testing.T) {
+	t.Parallel()
+
+	xErr := NewNotFoundError(errors.New("user not found"),
+		xerrors.WithInternalExtra(map[string]interface{}{xerrors.TraceIDKey: "trace-1"}))
+
+	xErrs := xerrors.NewXErrsLimited(1)
+	xErrs.Add(xErr, NewBadRequestError(errors.New("invalid email")))
+
+	rec := httptest.NewRecorder()
+	WriteErrors(rec, xErrs, nil)
+
+	if got := rec.Header().Get(HeaderTraceID); got != "trace-1" {
+		t.Errorf("%s header = %v, want trace-1", HeaderTraceID, got)
+	}
+
+	want := `{"errors":[{"message":"Not Found","extra":{"http_code":404,"trace_id":"trace-1"}},` +
+		`{"code":"truncated","message":"and 1 more","extra":{"dropped":{"":1}}}]}`
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body = %v, want %v", got, want)
+	}
+
+	if _, ok := xErr.GetExtra()[xerrors.TraceIDKey]; ok {
+		t.Errorf("WriteErrors() modified written error extra: %v", xErr.GetExtra())
+	}
+}
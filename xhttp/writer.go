@@ -0,0 +1,132 @@
+package xhttp
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+// WriteError writes xErr as JSON response with its HTTP status code,
+// http.StatusInternalServerError is used if xErr has no status code.
+// Retry-After header is set if xErr has retry delay hint, xerrors.Metrics is notified about returned error.
+// Trace ID from internal extra is echoed in X-Trace-Id header and in public extra of the response.
+// Sensitive information is not removed, call Sanitize before writing if needed.
+func WriteError(w http.ResponseWriter, xErr xerrors.XError) {
+	xerrors.ErrorReturned(xErr)
+	setRetryAfter(w, retryAfter(xErr))
+	setTraceID(w, traceID(xErr))
+	writeJSON(w, statusOrDefault(xErr), withTraceID(xErr))
+}
+
+// WriteErrors writes xErrs as JSON response with HTTP status code resolved by strategy,
+// DefaultStatusStrategy is used if strategy is nil.
+// Retry-After header is set to the longest retry delay hint of errors,
+// xerrors.Metrics is notified about every returned error.
+// Trace ID of the first error is echoed in X-Trace-Id header, trace IDs of all errors are added to public extra.
+// Sensitive information is not removed, call Sanitize before writing if needed.
+func WriteErrors(w http.ResponseWriter, xErrs *xerrors.XErrs, strategy StatusStrategy) {
+	var (
+		delay time.Duration
+		trace string
+	)
+
+	for _, xErr := range xErrs.GetErrors() {
+		xerrors.ErrorReturned(xErr)
+
+		if d := retryAfter(xErr); d > delay {
+			delay = d
+		}
+
+		if trace == "" {
+			trace = traceID(xErr)
+		}
+	}
+
+	setRetryAfter(w, delay)
+	setTraceID(w, trace)
+
+	resp := xErrs
+	if trace != "" {
+		cp := *xErrs
+		cp.Errs = make([]xerrors.XError, len(xErrs.Errs))
+
+		for i, xErr := range xErrs.Errs {
+			cp.Errs[i] = withTraceID(xErr)
+		}
+
+		resp = &cp
+	}
+
+	writeJSON(w, StatusCodeForErrors(xErrs, strategy), resp)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+
+	resp, err := json.Marshal(v)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(resp)
+}
+
+func retryAfter(xErr xerrors.XError) time.Duration {
+	if r, ok := xErr.(interface{ RetryAfter() time.Duration }); ok {
+		return r.RetryAfter()
+	}
+
+	return 0
+}
+
+func setRetryAfter(w http.ResponseWriter, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+
+	w.Header().Set("Retry-After", strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10))
+}
+
+func traceID(xErr xerrors.XError) string {
+	if xerrors.IsNil(xErr) {
+		return ""
+	}
+
+	id, _ := xErr.GetInternalExtra()[xerrors.TraceIDKey].(string)
+
+	return id
+}
+
+func setTraceID(w http.ResponseWriter, id string) {
+	if id != "" {
+		w.Header().Set(HeaderTraceID, id)
+	}
+}
+
+// withTraceID returns copy of xErr with trace ID added to public extra, xErr itself is not modified.
+func withTraceID(xErr xerrors.XError) xerrors.XError {
+	id := traceID(xErr)
+	x, ok := xErr.(*xerrors.XErr)
+
+	if id == "" || !ok {
+		return xErr
+	}
+
+	cp := *x
+	cp.Extra = make(map[string]interface{}, len(x.Extra)+1)
+
+	for k, v := range x.Extra {
+		cp.Extra[k] = v
+	}
+
+	cp.Extra[xerrors.TraceIDKey] = id
+
+	return &cp
+}
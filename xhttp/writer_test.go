@@ -0,0 +1,135 @@
+// nolint:goerr113
+package xhttp
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+func TestWriteError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		xErr     xerrors.XError
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "HTTP error",
+			xErr:     NewNotFoundError(errors.New("some error"), xerrors.WithDescription("user not found")),
+			wantCode: http.StatusNotFound,
+			wantBody: `{"message":"Not Found","description":"user not found","extra":{"http_code":404}}`,
+		},
+		{
+			name:     "error without status code",
+			xErr:     xerrors.New("some error"),
+			wantCode: http.StatusInternalServerError,
+			wantBody: `{"message":"some error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			WriteError(rec, tt.xErr)
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("WriteError() code = %v, want %v", rec.Code, tt.wantCode)
+			}
+
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("WriteError() body = %v, want %v", got, tt.wantBody)
+			}
+
+			if got := rec.Header().Get("Content-Type"); got != "application/json;charset=utf-8" {
+				t.Errorf("WriteError() Content-Type = %v, want application/json;charset=utf-8", got)
+			}
+		})
+	}
+}
+
+func TestWriteErrors(t *testing.T) {
+	t.Parallel()
+
+	xErrs := xerrors.NewXErrs()
+	xErrs.Add(
+		NewNotFoundError(errors.New("some error")),
+		NewBadRequestError(errors.New("some error")),
+	)
+
+	rec := httptest.NewRecorder()
+	WriteErrors(rec, xErrs, MultiStatus)
+
+	if rec.Code != http.StatusMultiStatus {
+		t.Errorf("WriteErrors() code = %v, want %v", rec.Code, http.StatusMultiStatus)
+	}
+
+	wantBody := `{"errors":[{"message":"Not Found","extra":{"http_code":404}},` +
+		`{"message":"Bad Request","extra":{"http_code":400}}]}`
+	if got := rec.Body.String(); got != wantBody {
+		t.Errorf("WriteErrors() body = %v, want %v", got, wantBody)
+	}
+}
+
+func TestWriteError_RetryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		xErr xerrors.XError
+		want string
+	}{
+		{
+			name: "without retry after",
+			xErr: NewServiceUnavailableError(errors.New("some error")),
+			want: "",
+		},
+		{
+			name: "with retry after",
+			xErr: NewTooManyRequestsError(errors.New("some error"), xerrors.WithRetryAfter(1500*time.Millisecond)),
+			want: "2",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			WriteError(rec, tt.xErr)
+
+			if got := rec.Header().Get("Retry-After"); got != tt.want {
+				t.Errorf("WriteError() Retry-After = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteErrors_RetryAfter(t *testing.T) {
+	t.Parallel()
+
+	xErrs := xerrors.NewXErrs()
+	xErrs.Add(
+		NewServiceUnavailableError(errors.New("some error"), xerrors.WithRetryAfter(time.Second)),
+		NewTooManyRequestsError(errors.New("some error"), xerrors.WithRetryAfter(time.Minute)),
+	)
+
+	rec := httptest.NewRecorder()
+	WriteErrors(rec, xErrs, nil)
+
+	if got := rec.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("WriteErrors() Retry-After = %v, want 60", got)
+	}
+}
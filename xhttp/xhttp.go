@@ -8,19 +8,41 @@ import (
 
 // NewError creates new HTTP XErr with following structure:
 // message: msg, extra: {"http_code": code}, internal_extra: {"error": err}.
+// Severity and kind are derived from the status code unless set by opts,
+// TooManyRequests(429) and ServiceUnavailable(503) errors are retryable by default.
+// The status code and the cause are merged into extra maps set by opts, so opts replacing extra maps,
+// e.g. xerrors.WithExtra, don't drop them, but they can be overwritten explicitly.
 func NewError(err error, msg string, code int, opts ...xerrors.XErrOpt) *xerrors.XErr {
 	if err == nil {
 		return nil
 	}
 
 	opts = append([]xerrors.XErrOpt{
-		xerrors.WithExtra(map[string]interface{}{"http_code": code}),
-		xerrors.WithInternalExtra(map[string]interface{}{"error": err}),
+		xerrors.WithSeverity(SeverityForStatus(code)),
+		xerrors.WithKind(KindForStatus(code)),
+		xerrors.WithRetryable(RetryableStatus(code)),
 	}, opts...)
+	opts = append(opts,
+		xerrors.DefaultExtra(map[string]interface{}{"http_code": code}),
+		xerrors.DefaultInternalExtra(map[string]interface{}{"error": err}),
+	)
 
 	return xerrors.New(msg, opts...)
 }
 
+// NewTemplate creates new HTTP xerrors.Template with the same defaults as NewError,
+// the cause is passed to xerrors.Template.New.
+func NewTemplate(msg string, code int, opts ...xerrors.XErrOpt) *xerrors.Template {
+	opts = append([]xerrors.XErrOpt{
+		xerrors.WithSeverity(SeverityForStatus(code)),
+		xerrors.WithKind(KindForStatus(code)),
+		xerrors.WithRetryable(RetryableStatus(code)),
+	}, opts...)
+	opts = append(opts, xerrors.DefaultExtra(map[string]interface{}{"http_code": code}))
+
+	return xerrors.NewTemplate(msg, opts...)
+}
+
 // NewBadRequestError creates new HTTP BadRequest(400) error.
 func NewBadRequestError(err error, opts ...xerrors.XErrOpt) *xerrors.XErr {
 	return NewError(err, "Bad Request", http.StatusBadRequest, opts...)
@@ -41,6 +63,16 @@ func NewNotFoundError(err error, opts ...xerrors.XErrOpt) *xerrors.XErr {
 	return NewError(err, "Not Found", http.StatusNotFound, opts...)
 }
 
+// NewTooManyRequestsError creates new HTTP TooManyRequests(429) error.
+func NewTooManyRequestsError(err error, opts ...xerrors.XErrOpt) *xerrors.XErr {
+	return NewError(err, "Too Many Requests", http.StatusTooManyRequests, opts...)
+}
+
+// NewConflictError creates new HTTP Conflict(409) error.
+func NewConflictError(err error, opts ...xerrors.XErrOpt) *xerrors.XErr {
+	return NewError(err, "Conflict", http.StatusConflict, opts...)
+}
+
 // NewUnprocessableEntityError creates new HTTP UnprocessableEntity(422) error.
 func NewUnprocessableEntityError(err error, opts ...xerrors.XErrOpt) *xerrors.XErr {
 	return NewError(err, "Unprocessable Entity", http.StatusUnprocessableEntity, opts...)
@@ -50,3 +82,8 @@ func NewUnprocessableEntityError(err error, opts ...xerrors.XErrOpt) *xerrors.XE
 func NewInternalServerError(err error, opts ...xerrors.XErrOpt) *xerrors.XErr {
 	return NewError(err, "Internal Server Error", http.StatusInternalServerError, opts...)
 }
+
+// NewServiceUnavailableError creates new HTTP ServiceUnavailable(503) error.
+func NewServiceUnavailableError(err error, opts ...xerrors.XErrOpt) *xerrors.XErr {
+	return NewError(err, "Service Unavailable", http.StatusServiceUnavailable, opts...)
+}
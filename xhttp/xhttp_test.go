@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/eugeneradionov/xerrors"
+	"github.com/eugeneradionov/xerrors/xerrorstest"
 )
 
 func TestNewBadRequestError(t *testing.T) {
@@ -39,6 +41,8 @@ func TestNewBadRequestError(t *testing.T) {
 			want: &xerrors.XErr{
 				Message:       "Bad Request",
 				Description:   "",
+				Severity:      xerrors.SeverityWarning,
+				Kind:          xerrors.KindValidation,
 				Extra:         map[string]interface{}{"http_code": http.StatusBadRequest},
 				InternalExtra: map[string]interface{}{"error": errors.New("db connection failed")},
 			},
@@ -55,6 +59,8 @@ func TestNewBadRequestError(t *testing.T) {
 			want: &xerrors.XErr{
 				Message:       "rewrite message",
 				Description:   "db connection failed",
+				Severity:      xerrors.SeverityWarning,
+				Kind:          xerrors.KindValidation,
 				Extra:         map[string]interface{}{"http_code": http.StatusBadRequest},
 				InternalExtra: map[string]interface{}{"error": errors.New("db connection failed")},
 			},
@@ -107,6 +113,8 @@ func TestNewError(t *testing.T) {
 			want: &xerrors.XErr{
 				Message:       "db connection failed",
 				Description:   "",
+				Severity:      xerrors.SeverityError,
+				Kind:          xerrors.KindInternal,
 				Extra:         map[string]interface{}{"http_code": http.StatusInternalServerError},
 				InternalExtra: map[string]interface{}{"error": errors.New("some error")},
 			},
@@ -124,10 +132,52 @@ func TestNewError(t *testing.T) {
 			want: &xerrors.XErr{
 				Message:       "db connection failed",
 				Description:   "description",
+				Severity:      xerrors.SeverityError,
+				Kind:          xerrors.KindInternal,
 				Extra:         map[string]interface{}{"http_code": http.StatusInternalServerError},
 				InternalExtra: map[string]interface{}{"error": errors.New("some error")},
 			},
 		},
+		{
+			name: "options replacing extra maps",
+			args: args{
+				err:  errors.New("some error"),
+				msg:  "db connection failed",
+				code: http.StatusInternalServerError,
+				opts: []xerrors.XErrOpt{
+					xerrors.WithExtra(map[string]interface{}{"table": "users"}),
+					xerrors.WithInternalExtra(map[string]interface{}{"query": "SELECT"}),
+				},
+			},
+			want: &xerrors.XErr{
+				Message:  "db connection failed",
+				Severity: xerrors.SeverityError,
+				Kind:     xerrors.KindInternal,
+				Extra:    map[string]interface{}{"http_code": http.StatusInternalServerError, "table": "users"},
+				InternalExtra: map[string]interface{}{
+					"error": errors.New("some error"),
+					"query": "SELECT",
+				},
+			},
+		},
+		{
+			name: "explicit status code",
+			args: args{
+				err:  errors.New("some error"),
+				msg:  "db connection failed",
+				code: http.StatusInternalServerError,
+				opts: []xerrors.XErrOpt{
+					xerrors.WithExtraField("http_code", http.StatusBadGateway),
+				},
+			},
+			want: &xerrors.XErr{
+				Message:       "db connection failed",
+				Severity:      xerrors.SeverityError,
+				Kind:          xerrors.KindInternal,
+				Extra:         map[string]interface{}{"http_code": http.StatusBadGateway},
+				InternalExtra: map[string]interface{}{"error": errors.New("some error")},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -172,6 +222,8 @@ func TestNewForbiddenError(t *testing.T) {
 			want: &xerrors.XErr{
 				Message:       "Forbidden",
 				Description:   "",
+				Severity:      xerrors.SeverityWarning,
+				Kind:          xerrors.KindAuth,
 				Extra:         map[string]interface{}{"http_code": http.StatusForbidden},
 				InternalExtra: map[string]interface{}{"error": errors.New("db connection failed")},
 			},
@@ -188,6 +240,8 @@ func TestNewForbiddenError(t *testing.T) {
 			want: &xerrors.XErr{
 				Message:       "rewrite message",
 				Description:   "db connection failed",
+				Severity:      xerrors.SeverityWarning,
+				Kind:          xerrors.KindAuth,
 				Extra:         map[string]interface{}{"http_code": http.StatusForbidden},
 				InternalExtra: map[string]interface{}{"error": errors.New("db connection failed")},
 			},
@@ -236,6 +290,8 @@ func TestNewInternalServerError(t *testing.T) {
 			want: &xerrors.XErr{
 				Message:       "Internal Server Error",
 				Description:   "",
+				Severity:      xerrors.SeverityError,
+				Kind:          xerrors.KindInternal,
 				Extra:         map[string]interface{}{"http_code": http.StatusInternalServerError},
 				InternalExtra: map[string]interface{}{"error": errors.New("db connection failed")},
 			},
@@ -252,6 +308,8 @@ func TestNewInternalServerError(t *testing.T) {
 			want: &xerrors.XErr{
 				Message:       "rewrite message",
 				Description:   "db connection failed",
+				Severity:      xerrors.SeverityError,
+				Kind:          xerrors.KindInternal,
 				Extra:         map[string]interface{}{"http_code": http.StatusInternalServerError},
 				InternalExtra: map[string]interface{}{"error": errors.New("db connection failed")},
 			},
@@ -299,6 +357,8 @@ func TestNewNotFoundError(t *testing.T) {
 			want: &xerrors.XErr{
 				Message:       "Not Found",
 				Description:   "",
+				Severity:      xerrors.SeverityInfo,
+				Kind:          xerrors.KindNotFound,
 				Extra:         map[string]interface{}{"http_code": http.StatusNotFound},
 				InternalExtra: map[string]interface{}{"error": errors.New("db connection failed")},
 			},
@@ -315,6 +375,8 @@ func TestNewNotFoundError(t *testing.T) {
 			want: &xerrors.XErr{
 				Message:       "rewrite message",
 				Description:   "db connection failed",
+				Severity:      xerrors.SeverityInfo,
+				Kind:          xerrors.KindNotFound,
 				Extra:         map[string]interface{}{"http_code": http.StatusNotFound},
 				InternalExtra: map[string]interface{}{"error": errors.New("db connection failed")},
 			},
@@ -334,6 +396,74 @@ func TestNewNotFoundError(t *testing.T) {
 	}
 }
 
+func TestNewConflictError(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		err  error
+		opts []xerrors.XErrOpt
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want *xerrors.XErr
+	}{
+		{
+			name: "nil error",
+			args: args{
+				err: nil,
+			},
+			want: nil,
+		},
+		{
+			name: "error without options",
+			args: args{
+				err:  errors.New("db connection failed"),
+				opts: nil,
+			},
+			want: &xerrors.XErr{
+				Message:       "Conflict",
+				Description:   "",
+				Severity:      xerrors.SeverityWarning,
+				Kind:          xerrors.KindConflict,
+				Extra:         map[string]interface{}{"http_code": http.StatusConflict},
+				InternalExtra: map[string]interface{}{"error": errors.New("db connection failed")},
+			},
+		},
+		{
+			name: "error with options",
+			args: args{
+				err: errors.New("db connection failed"),
+				opts: []xerrors.XErrOpt{
+					xerrors.WithMessage("rewrite message"),
+					xerrors.WithDescription("db connection failed"),
+				},
+			},
+			want: &xerrors.XErr{
+				Message:       "rewrite message",
+				Description:   "db connection failed",
+				Severity:      xerrors.SeverityWarning,
+				Kind:          xerrors.KindConflict,
+				Extra:         map[string]interface{}{"http_code": http.StatusConflict},
+				InternalExtra: map[string]interface{}{"error": errors.New("db connection failed")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := NewConflictError(tt.args.err, tt.args.opts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewConflictError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNewUnauthorizedError(t *testing.T) {
 	t.Parallel()
 
@@ -363,6 +493,8 @@ func TestNewUnauthorizedError(t *testing.T) {
 			want: &xerrors.XErr{
 				Message:       "Unauthorized",
 				Description:   "",
+				Severity:      xerrors.SeverityWarning,
+				Kind:          xerrors.KindAuth,
 				Extra:         map[string]interface{}{"http_code": http.StatusUnauthorized},
 				InternalExtra: map[string]interface{}{"error": errors.New("db connection failed")},
 			},
@@ -379,6 +511,8 @@ func TestNewUnauthorizedError(t *testing.T) {
 			want: &xerrors.XErr{
 				Message:       "rewrite message",
 				Description:   "db connection failed",
+				Severity:      xerrors.SeverityWarning,
+				Kind:          xerrors.KindAuth,
 				Extra:         map[string]interface{}{"http_code": http.StatusUnauthorized},
 				InternalExtra: map[string]interface{}{"error": errors.New("db connection failed")},
 			},
@@ -426,6 +560,8 @@ func TestNewUnprocessableEntityError(t *testing.T) {
 			want: &xerrors.XErr{
 				Message:       "Unprocessable Entity",
 				Description:   "",
+				Severity:      xerrors.SeverityWarning,
+				Kind:          xerrors.KindValidation,
 				Extra:         map[string]interface{}{"http_code": http.StatusUnprocessableEntity},
 				InternalExtra: map[string]interface{}{"error": errors.New("db connection failed")},
 			},
@@ -442,6 +578,8 @@ func TestNewUnprocessableEntityError(t *testing.T) {
 			want: &xerrors.XErr{
 				Message:       "rewrite message",
 				Description:   "db connection failed",
+				Severity:      xerrors.SeverityWarning,
+				Kind:          xerrors.KindValidation,
 				Extra:         map[string]interface{}{"http_code": http.StatusUnprocessableEntity},
 				InternalExtra: map[string]interface{}{"error": errors.New("db connection failed")},
 			},
@@ -460,3 +598,162 @@ func TestNewUnprocessableEntityError(t *testing.T) {
 		})
 	}
 }
+
+func TestNewTooManyRequestsError(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		err  error
+		opts []xerrors.XErrOpt
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want *xerrors.XErr
+	}{
+		{
+			name: "nil error",
+			args: args{
+				err: nil,
+			},
+			want: nil,
+		},
+		{
+			name: "error without options",
+			args: args{
+				err:  errors.New("db connection failed"),
+				opts: nil,
+			},
+			want: xerrors.New("Too Many Requests",
+				xerrors.WithSeverity(SeverityForStatus(http.StatusTooManyRequests)),
+				xerrors.WithKind(xerrors.KindTransient),
+				xerrors.WithRetryable(true),
+				xerrors.WithExtra(map[string]interface{}{"http_code": http.StatusTooManyRequests}),
+				xerrors.WithInternalExtra(map[string]interface{}{"error": errors.New("db connection failed")}),
+			),
+		},
+		{
+			name: "error with options",
+			args: args{
+				err: errors.New("db connection failed"),
+				opts: []xerrors.XErrOpt{
+					xerrors.WithMessage("rewrite message"),
+					xerrors.WithRetryAfter(time.Minute),
+				},
+			},
+			want: xerrors.New("rewrite message",
+				xerrors.WithSeverity(SeverityForStatus(http.StatusTooManyRequests)),
+				xerrors.WithKind(xerrors.KindTransient),
+				xerrors.WithRetryAfter(time.Minute),
+				xerrors.WithExtra(map[string]interface{}{"http_code": http.StatusTooManyRequests}),
+				xerrors.WithInternalExtra(map[string]interface{}{"error": errors.New("db connection failed")}),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := NewTooManyRequestsError(tt.args.err, tt.args.opts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewTooManyRequestsError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewServiceUnavailableError(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		err  error
+		opts []xerrors.XErrOpt
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want *xerrors.XErr
+	}{
+		{
+			name: "nil error",
+			args: args{
+				err: nil,
+			},
+			want: nil,
+		},
+		{
+			name: "error without options",
+			args: args{
+				err:  errors.New("db connection failed"),
+				opts: nil,
+			},
+			want: xerrors.New("Service Unavailable",
+				xerrors.WithSeverity(SeverityForStatus(http.StatusServiceUnavailable)),
+				xerrors.WithKind(xerrors.KindTransient),
+				xerrors.WithRetryable(true),
+				xerrors.WithExtra(map[string]interface{}{"http_code": http.StatusServiceUnavailable}),
+				xerrors.WithInternalExtra(map[string]interface{}{"error": errors.New("db connection failed")}),
+			),
+		},
+		{
+			name: "error with options",
+			args: args{
+				err: errors.New("db connection failed"),
+				opts: []xerrors.XErrOpt{
+					xerrors.WithMessage("rewrite message"),
+					xerrors.WithRetryAfter(time.Minute),
+				},
+			},
+			want: xerrors.New("rewrite message",
+				xerrors.WithSeverity(SeverityForStatus(http.StatusServiceUnavailable)),
+				xerrors.WithKind(xerrors.KindTransient),
+				xerrors.WithRetryAfter(time.Minute),
+				xerrors.WithExtra(map[string]interface{}{"http_code": http.StatusServiceUnavailable}),
+				xerrors.WithInternalExtra(map[string]interface{}{"error": errors.New("db connection failed")}),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := NewServiceUnavailableError(tt.args.err, tt.args.opts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewServiceUnavailableError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTemplate(t *testing.T) {
+	t.Parallel()
+
+	tmpl := NewTemplate("User Not Found", http.StatusNotFound,
+		xerrors.WithCode("user_not_found"),
+		xerrors.WithExtra(map[string]interface{}{"resource": "user"}),
+	)
+
+	cause := errors.New("no rows")
+	xErr := tmpl.New(cause)
+
+	want := &xerrors.XErr{
+		Code:          "user_not_found",
+		Message:       "User Not Found",
+		Severity:      xerrors.SeverityInfo,
+		Kind:          xerrors.KindNotFound,
+		Extra:         map[string]interface{}{"http_code": http.StatusNotFound, "resource": "user"},
+		InternalExtra: map[string]interface{}{"error": cause},
+	}
+
+	xerrorstest.AssertXErr(t, xErr, want, xerrorstest.Opts{})
+
+	if !errors.Is(xErr, tmpl) {
+		t.Errorf("errors.Is(New(), template) = false, want true")
+	}
+}
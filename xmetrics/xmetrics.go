@@ -0,0 +1,112 @@
+package xmetrics
+
+import (
+	"expvar"
+	"sync"
+
+	"github.com/eugeneradionov/xerrors"
+	"github.com/eugeneradionov/xerrors/xhttp"
+)
+
+// Counts contains numbers of errors per code, HTTP status code and severity.
+type Counts struct {
+	Total      int64            `json:"total"`
+	ByCode     map[string]int64 `json:"by_code"`
+	ByStatus   map[int]int64    `json:"by_status"`
+	BySeverity map[string]int64 `json:"by_severity"`
+}
+
+// Snapshot contains numbers of created and returned errors at some moment.
+type Snapshot struct {
+	Created  Counts `json:"created"`
+	Returned Counts `json:"returned"`
+}
+
+// Counters is in-process xerrors.Metrics implementation that counts errors in memory.
+type Counters struct {
+	mu       sync.Mutex
+	created  Counts
+	returned Counts
+}
+
+// NewCounters returns new instance of Counters.
+func NewCounters() *Counters {
+	return &Counters{
+		created:  newCounts(),
+		returned: newCounts(),
+	}
+}
+
+// ErrorCreated counts created error.
+func (c *Counters) ErrorCreated(xErr xerrors.XError) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.created.add(xErr)
+}
+
+// ErrorReturned counts returned error.
+func (c *Counters) ErrorReturned(xErr xerrors.XError) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.returned.add(xErr)
+}
+
+// Snapshot returns copy of current counters.
+func (c *Counters) Snapshot() Snapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return Snapshot{
+		Created:  c.created.clone(),
+		Returned: c.returned.clone(),
+	}
+}
+
+// Publish exposes counters snapshot via expvar under name, it panics if the name is already registered.
+func (c *Counters) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} { return c.Snapshot() }))
+}
+
+func newCounts() Counts {
+	return Counts{
+		ByCode:     make(map[string]int64),
+		ByStatus:   make(map[int]int64),
+		BySeverity: make(map[string]int64),
+	}
+}
+
+func (c *Counts) add(xErr xerrors.XError) {
+	if xerrors.IsNil(xErr) {
+		return
+	}
+
+	c.Total++
+	c.ByCode[xerrors.CodeKey(xErr)]++
+	c.ByStatus[xhttp.StatusCode(xErr)]++
+	c.BySeverity[xErr.GetSeverity().String()]++
+}
+
+func (c *Counts) clone() Counts {
+	cp := Counts{
+		Total:      c.Total,
+		ByCode:     make(map[string]int64, len(c.ByCode)),
+		ByStatus:   make(map[int]int64, len(c.ByStatus)),
+		BySeverity: make(map[string]int64, len(c.BySeverity)),
+	}
+
+	for k, v := range c.ByCode {
+		cp.ByCode[k] = v
+	}
+
+	for k, v := range c.ByStatus {
+		cp.ByStatus[k] = v
+	}
+
+	for k, v := range c.BySeverity {
+		cp.BySeverity[k] = v
+	}
+
+	return cp
+}
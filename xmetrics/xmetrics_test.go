@@ -0,0 +1,63 @@
+// nolint:goerr113
+package xmetrics
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/eugeneradionov/xerrors"
+	"github.com/eugeneradionov/xerrors/xhttp"
+)
+
+// nolint:paralleltest // xerrors.SetMetrics changes global state.
+func TestCounters(t *testing.T) {
+	c := NewCounters()
+
+	xerrors.SetMetrics(c)
+	defer xerrors.SetMetrics(nil)
+
+	notFound := xhttp.NewNotFoundError(errors.New("some error"), xerrors.WithCode("user_not_found"))
+	xhttp.NewInternalServerError(errors.New("some error"))
+	xerrors.New("some error", xerrors.WithCode("user_not_found"), xerrors.WithSeverity(xerrors.SeverityCritical))
+
+	xhttp.WriteError(httptest.NewRecorder(), notFound)
+
+	want := Snapshot{
+		Created: Counts{
+			Total:      3,
+			ByCode:     map[string]int64{"user_not_found": 2, "": 1},
+			ByStatus:   map[int]int64{http.StatusNotFound: 1, http.StatusInternalServerError: 1, 0: 1},
+			BySeverity: map[string]int64{"info": 1, "error": 1, "critical": 1},
+		},
+		Returned: Counts{
+			Total:      1,
+			ByCode:     map[string]int64{"user_not_found": 1},
+			ByStatus:   map[int]int64{http.StatusNotFound: 1},
+			BySeverity: map[string]int64{"info": 1},
+		},
+	}
+
+	got := c.Snapshot()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Snapshot() = %+v, want %+v", got, want)
+	}
+
+	name := "xmetrics_test_" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	c.Publish(name)
+
+	var published Snapshot
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &published); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+
+	if !reflect.DeepEqual(published, want) {
+		t.Errorf("expvar snapshot = %+v, want %+v", published, want)
+	}
+}
@@ -0,0 +1,54 @@
+package xos
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+
+	"github.com/eugeneradionov/xerrors"
+	"github.com/eugeneradionov/xerrors/xhttp"
+)
+
+// Map converts filesystem error into XErr:
+//   - fs.ErrNotExist into NotFound(404);
+//   - fs.ErrPermission into Forbidden(403);
+//   - fs.ErrExist into Conflict(409);
+//   - any other error into InternalServerError(500).
+//
+// Operation and path of *fs.PathError (*os.PathError) are added to internal extra under the "op" and "path" keys,
+// *os.LinkError adds "op", "path" and "new_path". Paths are never exposed in public extra.
+// Original error is kept as a cause. It returns nil if err is nil.
+func Map(err error, opts ...xerrors.XErrOpt) *xerrors.XErr {
+	if err == nil {
+		return nil
+	}
+
+	if paths := pathsOf(err); paths != nil {
+		opts = append(append(make([]xerrors.XErrOpt, 0, len(opts)+1), opts...), xerrors.MergeInternalExtra(paths))
+	}
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return xhttp.NewNotFoundError(err, opts...)
+	case errors.Is(err, fs.ErrPermission):
+		return xhttp.NewForbiddenError(err, opts...)
+	case errors.Is(err, fs.ErrExist):
+		return xhttp.NewConflictError(err, opts...)
+	default:
+		return xhttp.NewInternalServerError(err, opts...)
+	}
+}
+
+func pathsOf(err error) map[string]interface{} {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return map[string]interface{}{"op": pathErr.Op, "path": pathErr.Path}
+	}
+
+	var linkErr *os.LinkError
+	if errors.As(err, &linkErr) {
+		return map[string]interface{}{"op": linkErr.Op, "path": linkErr.Old, "new_path": linkErr.New}
+	}
+
+	return nil
+}
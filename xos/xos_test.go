@@ -0,0 +1,161 @@
+// nolint:goerr113
+package xos
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/eugeneradionov/xerrors"
+	"github.com/eugeneradionov/xerrors/xhttp"
+)
+
+func TestMap(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.txt")
+	existing := filepath.Join(dir, "existing")
+
+	if err := os.Mkdir(existing, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	_, notExistErr := os.Open(missing)
+	existErr := os.Mkdir(existing, 0o700)
+	linkErr := os.Symlink(missing, existing)
+
+	tests := []struct {
+		name         string
+		err          error
+		wantNil      bool
+		wantCode     int
+		wantInternal map[string]interface{}
+	}{
+		{
+			name:    "nil error",
+			err:     nil,
+			wantNil: true,
+		},
+		{
+			name:         "not exist",
+			err:          notExistErr,
+			wantCode:     http.StatusNotFound,
+			wantInternal: map[string]interface{}{"error": notExistErr, "op": "open", "path": missing},
+		},
+		{
+			name:         "exist",
+			err:          fmt.Errorf("create upload dir: %w", existErr),
+			wantCode:     http.StatusConflict,
+			wantInternal: map[string]interface{}{"op": "mkdir", "path": existing},
+		},
+		{
+			name:         "link error",
+			err:          linkErr,
+			wantCode:     http.StatusConflict,
+			wantInternal: map[string]interface{}{"op": "symlink", "path": missing, "new_path": existing},
+		},
+		{
+			name:         "permission",
+			err:          &fs.PathError{Op: "open", Path: "/etc/shadow", Err: fs.ErrPermission},
+			wantCode:     http.StatusForbidden,
+			wantInternal: map[string]interface{}{"op": "open", "path": "/etc/shadow"},
+		},
+		{
+			name:         "other error",
+			err:          errors.New("disk failure"),
+			wantCode:     http.StatusInternalServerError,
+			wantInternal: map[string]interface{}{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Map(tt.err)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("Map() = %v, want nil", got)
+				}
+
+				return
+			}
+
+			if code := xhttp.StatusCode(got); code != tt.wantCode {
+				t.Errorf("Map() status code = %v, want %v", code, tt.wantCode)
+			}
+
+			if !errors.Is(got, tt.err) {
+				t.Errorf("Map() cause = %v, want %v", got.Unwrap(), tt.err)
+			}
+
+			for k, want := range tt.wantInternal {
+				if v := got.GetInternalExtra()[k]; !reflect.DeepEqual(v, want) {
+					t.Errorf("Map() internal extra[%s] = %v, want %v", k, v, want)
+				}
+			}
+
+			if want := map[string]interface{}{"http_code": tt.wantCode}; !reflect.DeepEqual(got.GetExtra(), want) {
+				t.Errorf("Map() extra = %v, want %v", got.GetExtra(), want)
+			}
+		})
+	}
+}
+
+func TestMap_InternalExtraNotShared(t *testing.T) {
+	t.Parallel()
+
+	intExtra := map[string]interface{}{"upload_id": 1}
+	err := &fs.PathError{Op: "open", Path: "/tmp/upload", Err: fs.ErrNotExist}
+
+	got := Map(err, xerrors.WithInternalExtra(intExtra))
+
+	if _, ok := intExtra["path"]; ok {
+		t.Errorf("Map() modified internal extra passed in options: %v", intExtra)
+	}
+
+	if got.GetInternalExtra()["upload_id"] != 1 || got.GetInternalExtra()["path"] != "/tmp/upload" {
+		t.Errorf("Map() internal extra = %v", got.GetInternalExtra())
+	}
+}
+
+func TestMap_Hooks(t *testing.T) {
+	t.Parallel()
+
+	const code = "xos_hooks_test"
+
+	var (
+		mu   sync.Mutex
+		seen map[string]interface{}
+	)
+
+	t.Cleanup(xerrors.OnNew(func(xErr *xerrors.XErr) {
+		if xErr.GetCode() != code {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		seen = xErr.GetInternalExtra()
+	}))
+
+	err := &fs.PathError{Op: "open", Path: "/etc/secret", Err: fs.ErrNotExist}
+	Map(err, xerrors.WithCode(code))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if seen["op"] != "open" || seen["path"] != "/etc/secret" {
+		t.Errorf("OnNew hook saw internal extra %v, want op and path", seen)
+	}
+}
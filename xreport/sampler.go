@@ -0,0 +1,54 @@
+package xreport
+
+import (
+	"sync"
+	"time"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+// Sampler reports whether xErr should be reported.
+type Sampler func(xErr xerrors.XError) bool
+
+// PerFingerprint returns Sampler that passes at most n errors with the same fingerprint per window,
+// so a flood of the same error doesn't hide the other ones. Fingerprint stored by xerrors.WithFingerprint
+// is used if present, otherwise it's computed with xerrors.Fingerprint.
+func PerFingerprint(n int, window time.Duration) Sampler {
+	return perFingerprint(n, window, time.Now)
+}
+
+func perFingerprint(n int, window time.Duration, now func() time.Time) Sampler {
+	var (
+		mu     sync.Mutex
+		start  time.Time
+		counts = make(map[string]int)
+	)
+
+	return func(xErr xerrors.XError) bool {
+		fp := fingerprint(xErr)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if t := now(); t.Sub(start) >= window {
+			start = t
+			counts = make(map[string]int)
+		}
+
+		if counts[fp] >= n {
+			return false
+		}
+
+		counts[fp]++
+
+		return true
+	}
+}
+
+func fingerprint(xErr xerrors.XError) string {
+	if fp, ok := xErr.GetInternalExtra()[xerrors.FingerprintKey].(string); ok {
+		return fp
+	}
+
+	return xerrors.Fingerprint(xErr)
+}
@@ -0,0 +1,42 @@
+package xreport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+func TestPerFingerprint(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	sample := perFingerprint(2, time.Minute, func() time.Time { return now })
+
+	a1 := xerrors.New("user 1 not found", xerrors.WithCode("not_found"))
+	a2 := xerrors.New("user 2 not found", xerrors.WithCode("not_found"))
+	b := xerrors.New("user 3 not found", xerrors.WithCode("not_found"),
+		xerrors.WithInternalExtra(map[string]interface{}{xerrors.FingerprintKey: "custom"}))
+
+	steps := []struct {
+		name    string
+		advance time.Duration
+		xErr    xerrors.XError
+		want    bool
+	}{
+		{name: "first", xErr: a1, want: true},
+		{name: "same fingerprint", xErr: a2, want: true},
+		{name: "over limit", xErr: a1, want: false},
+		{name: "stored fingerprint", xErr: b, want: true},
+		{name: "within window", advance: 30 * time.Second, xErr: a2, want: false},
+		{name: "next window", advance: 30 * time.Second, xErr: a2, want: true},
+	}
+
+	for _, step := range steps {
+		now = now.Add(step.advance)
+
+		if got := sample(step.xErr); got != step.want {
+			t.Errorf("%s: sample() = %v, want %v", step.name, got, step.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package xreport
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+// NDJSONSink is Sink that writes errors as newline delimited JSON, one error per line.
+// Errors are encoded with internal fields, see xerrors.XErr.MarshalInternalJSON.
+// It's meant for local development and tests.
+type NDJSONSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewNDJSONSink returns new NDJSONSink writing to w.
+func NewNDJSONSink(w io.Writer) *NDJSONSink {
+	return &NDJSONSink{w: w}
+}
+
+// OpenFile returns new NDJSONSink appending to the file at path, the file is created if it doesn't exist.
+// Close must be called to close the file.
+func OpenFile(path string) (*NDJSONSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644) // nolint:gomnd
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNDJSONSink(f), nil
+}
+
+// Write writes batch to the underlying writer.
+func (s *NDJSONSink) Write(batch []xerrors.XError) error {
+	var buf []byte
+
+	for _, xErr := range batch {
+		line, err := marshal(xErr)
+		if err != nil {
+			return err
+		}
+
+		buf = append(append(buf, line...), '\n')
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.w.Write(buf)
+
+	return err
+}
+
+// Close closes the underlying writer if it implements io.Closer.
+func (s *NDJSONSink) Close() error {
+	if c, ok := s.w.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+func marshal(xErr xerrors.XError) ([]byte, error) {
+	if m, ok := xErr.(interface{ MarshalInternalJSON() ([]byte, error) }); ok {
+		return m.MarshalInternalJSON()
+	}
+
+	return json.Marshal(xErr)
+}
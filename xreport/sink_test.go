@@ -0,0 +1,65 @@
+package xreport
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+func TestNDJSONSink_Write(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	sink := NewNDJSONSink(&buf)
+
+	err := sink.Write([]xerrors.XError{
+		xerrors.New("test msg", xerrors.WithCode("test_code")),
+		xerrors.New("test msg 2", xerrors.WithInternalExtra(map[string]interface{}{"error": errors.New("cause")})),
+	})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	want := `{"code":"test_code","message":"test msg","severity":"error"}` + "\n" +
+		`{"message":"test msg 2","severity":"error","internal_extra":{"error":"cause"}}` + "\n"
+
+	if got := buf.String(); got != want {
+		t.Errorf("Write() wrote %s, want %s", got, want)
+	}
+}
+
+func TestOpenFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "errors.ndjson")
+
+	for i := 0; i < 2; i++ {
+		sink, err := OpenFile(path)
+		if err != nil {
+			t.Fatalf("OpenFile() error = %v", err)
+		}
+
+		if err := sink.Write([]xerrors.XError{xerrors.New("test msg")}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+
+		if err := sink.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+
+	line := `{"message":"test msg","severity":"error"}` + "\n"
+	if want := line + line; string(got) != want {
+		t.Errorf("file content %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,256 @@
+package xreport
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/eugeneradionov/xerrors"
+	"github.com/eugeneradionov/xerrors/xhttp"
+)
+
+const (
+	defaultQueueSize     = 1024
+	defaultBatchSize     = 100
+	defaultFlushInterval = 5 * time.Second
+)
+
+// Reporter reports errors to an error-tracking backend.
+type Reporter interface {
+	Report(xErr xerrors.XError)
+}
+
+// Sink writes batches of errors to an error-tracking backend.
+type Sink interface {
+	Write(batch []xerrors.XError) error
+}
+
+// Policy defines what Report does when the queue is full.
+type Policy int
+
+const (
+	// Drop drops reported error when the queue is full, Report never blocks.
+	Drop Policy = iota
+	// Block blocks Report until there is room in the queue.
+	Block
+)
+
+// Opts configures Async reporter. Zero fields are replaced with defaults.
+type Opts struct {
+	// QueueSize is the maximum number of errors waiting to be written, 1024 by default.
+	QueueSize int
+	// BatchSize is the maximum number of errors written to Sink at once, 100 by default.
+	BatchSize int
+	// FlushInterval is the maximum time error waits in incomplete batch, 5s by default.
+	FlushInterval time.Duration
+	// Policy defines what to do when the queue is full, Drop by default.
+	Policy Policy
+	// Filter selects errors to report, ServerErrors by default.
+	Filter func(xErr xerrors.XError) bool
+	// Sampler selects errors to report after Filter, all errors are reported by default.
+	Sampler Sampler
+	// OnError is called with errors returned by Sink.
+	OnError func(err error)
+}
+
+// ServerErrors reports whether xErr is a server error, errors without HTTP status code are treated as server errors.
+func ServerErrors(xErr xerrors.XError) bool {
+	code := xhttp.StatusCode(xErr)
+
+	return code == 0 || code >= http.StatusInternalServerError
+}
+
+// Async is Reporter that writes errors to Sink in batches from a background goroutine.
+type Async struct {
+	sink Sink
+	opts Opts
+
+	mu     sync.RWMutex
+	closed bool
+
+	queue    chan xerrors.XError
+	flush    chan chan struct{}
+	stop     chan struct{}
+	finished chan struct{}
+	dropped  atomic.Int64
+}
+
+// NewAsync returns new Async reporter writing to sink and starts its background goroutine.
+// Close must be called on shutdown to write remaining errors.
+func NewAsync(sink Sink, opts Opts) *Async {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = defaultQueueSize
+	}
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = defaultFlushInterval
+	}
+
+	if opts.Filter == nil {
+		opts.Filter = ServerErrors
+	}
+
+	r := &Async{
+		sink:     sink,
+		opts:     opts,
+		queue:    make(chan xerrors.XError, opts.QueueSize),
+		flush:    make(chan chan struct{}),
+		stop:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+
+	go r.run()
+
+	return r
+}
+
+// Report queues xErr to be written if it passes filter and sampler.
+// *xerrors.XErr is cloned, so later changes of xErr, e.g. Sanitize, don't affect the reported error,
+// other XError implementations must not be modified after they are reported.
+// Errors reported after Close are dropped.
+func (r *Async) Report(xErr xerrors.XError) {
+	if xerrors.IsNil(xErr) || !r.opts.Filter(xErr) {
+		return
+	}
+
+	if r.opts.Sampler != nil && !r.opts.Sampler(xErr) {
+		return
+	}
+
+	if x, ok := xErr.(*xerrors.XErr); ok {
+		xErr = x.Clone()
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		r.dropped.Add(1)
+
+		return
+	}
+
+	if r.opts.Policy == Block {
+		r.queue <- xErr
+
+		return
+	}
+
+	select {
+	case r.queue <- xErr:
+	default:
+		r.dropped.Add(1)
+	}
+}
+
+// Dropped returns number of errors dropped because the queue was full or the reporter was closed.
+func (r *Async) Dropped() int64 {
+	return r.dropped.Load()
+}
+
+// Flush writes all queued errors and waits until they are written or ctx is done.
+func (r *Async) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+
+	select {
+	case r.flush <- done:
+	case <-r.finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops the reporter and waits until queued errors are written or ctx is done.
+func (r *Async) Close(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.stop)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Async) run() {
+	defer close(r.finished)
+
+	ticker := time.NewTicker(r.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := r.newBatch()
+
+	for {
+		select {
+		case xErr := <-r.queue:
+			batch = r.add(batch, xErr)
+		case <-ticker.C:
+			batch = r.write(batch)
+		case done := <-r.flush:
+			batch = r.write(r.drain(batch))
+			close(done)
+		case <-r.stop:
+			r.write(r.drain(batch))
+
+			return
+		}
+	}
+}
+
+// add appends xErr to batch and writes it when it's full.
+func (r *Async) add(batch []xerrors.XError, xErr xerrors.XError) []xerrors.XError {
+	batch = append(batch, xErr)
+	if len(batch) >= r.opts.BatchSize {
+		return r.write(batch)
+	}
+
+	return batch
+}
+
+// drain moves all queued errors to batch without waiting for new ones.
+func (r *Async) drain(batch []xerrors.XError) []xerrors.XError {
+	for {
+		select {
+		case xErr := <-r.queue:
+			batch = r.add(batch, xErr)
+		default:
+			return batch
+		}
+	}
+}
+
+// write writes not empty batch to sink and returns new batch, as sink may retain the written one.
+func (r *Async) write(batch []xerrors.XError) []xerrors.XError {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	if err := r.sink.Write(batch); err != nil && r.opts.OnError != nil {
+		r.opts.OnError(err)
+	}
+
+	return r.newBatch()
+}
+
+func (r *Async) newBatch() []xerrors.XError {
+	return make([]xerrors.XError, 0, r.opts.BatchSize)
+}
@@ -0,0 +1,339 @@
+// nolint:funlen
+package xreport
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eugeneradionov/xerrors"
+	"github.com/eugeneradionov/xerrors/xhttp"
+)
+
+type memSink struct {
+	mu      sync.Mutex
+	batches [][]xerrors.XError
+	block   chan struct{}
+	err     error
+}
+
+func (s *memSink) Write(batch []xerrors.XError) error {
+	if s.block != nil {
+		<-s.block
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.batches = append(s.batches, batch)
+
+	return s.err
+}
+
+func (s *memSink) messages() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var msgs []string
+
+	for _, batch := range s.batches {
+		for _, xErr := range batch {
+			msgs = append(msgs, xErr.GetMessage())
+		}
+	}
+
+	return msgs
+}
+
+func (s *memSink) sizes() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sizes := make([]int, 0, len(s.batches))
+	for _, batch := range s.batches {
+		sizes = append(sizes, len(batch))
+	}
+
+	return sizes
+}
+
+func TestServerErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		xErr xerrors.XError
+		want bool
+	}{
+		{
+			name: "without status",
+			xErr: xerrors.New("test msg"),
+			want: true,
+		},
+		{
+			name: "client error",
+			xErr: xhttp.NewNotFoundError(errors.New("not found")),
+			want: false,
+		},
+		{
+			name: "server error",
+			xErr: xhttp.NewServiceUnavailableError(errors.New("unavailable")),
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ServerErrors(tt.xErr); got != tt.want {
+				t.Errorf("ServerErrors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAsync_Report(t *testing.T) {
+	t.Parallel()
+
+	sink := &memSink{}
+	r := NewAsync(sink, Opts{BatchSize: 2, FlushInterval: time.Hour})
+
+	r.Report(nil)
+	r.Report(xhttp.NewBadRequestError(errors.New("bad request")))
+
+	for i := 0; i < 5; i++ {
+		r.Report(xerrors.New(strconv.Itoa(i)))
+	}
+
+	if err := r.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if got, want := sink.messages(), []string{"0", "1", "2", "3", "4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reported %v, want %v", got, want)
+	}
+
+	if got, want := sink.sizes(), []int{2, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("batch sizes %v, want %v", got, want)
+	}
+
+	r.Report(xerrors.New("after close"))
+
+	if got := r.Dropped(); got != 1 {
+		t.Errorf("Dropped() = %v, want 1", got)
+	}
+}
+
+// TestAsync_ReportSnapshot must be run with -race.
+func TestAsync_ReportSnapshot(t *testing.T) {
+	t.Parallel()
+
+	sink := &memSink{}
+	r := NewAsync(sink, Opts{FlushInterval: time.Millisecond})
+
+	xErr := xerrors.New("test msg",
+		xerrors.WithDescription("secret"),
+		xerrors.WithExtra(map[string]interface{}{"key": "value"}),
+	)
+
+	r.Report(xErr)
+
+	xErr.Sanitize()
+	xErr.Extra["key"] = "modified"
+
+	if err := r.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+
+	got := sink.batches[0][0]
+
+	if got.GetDescription() != "secret" || got.GetExtra()["key"] != "value" {
+		t.Errorf("reported %v, want error as it was at Report time", got)
+	}
+}
+
+func TestAsync_Flush(t *testing.T) {
+	t.Parallel()
+
+	sink := &memSink{}
+	r := NewAsync(sink, Opts{FlushInterval: time.Hour})
+
+	defer r.Close(context.Background()) // nolint:errcheck
+
+	r.Report(xerrors.New("test msg"))
+
+	if err := r.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if got, want := sink.messages(), []string{"test msg"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reported %v, want %v", got, want)
+	}
+}
+
+func TestAsync_FlushInterval(t *testing.T) {
+	t.Parallel()
+
+	sink := &memSink{}
+	r := NewAsync(sink, Opts{FlushInterval: time.Millisecond})
+
+	defer r.Close(context.Background()) // nolint:errcheck
+
+	r.Report(xerrors.New("test msg"))
+
+	deadline := time.Now().Add(time.Second)
+	for len(sink.messages()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if got, want := sink.messages(), []string{"test msg"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reported %v, want %v", got, want)
+	}
+}
+
+func TestAsync_Drop(t *testing.T) {
+	t.Parallel()
+
+	sink := &memSink{block: make(chan struct{})}
+	r := NewAsync(sink, Opts{QueueSize: 1, BatchSize: 1, FlushInterval: time.Hour})
+
+	// the first error blocks in sink, the second one waits in the queue, the rest are dropped.
+	r.Report(xerrors.New("0"))
+
+	for len(r.queue) != 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	for i := 1; i < 5; i++ {
+		r.Report(xerrors.New(strconv.Itoa(i)))
+	}
+
+	close(sink.block)
+
+	if err := r.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if got, want := sink.messages(), []string{"0", "1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reported %v, want %v", got, want)
+	}
+
+	if got := r.Dropped(); got != 3 {
+		t.Errorf("Dropped() = %v, want 3", got)
+	}
+}
+
+func TestAsync_Block(t *testing.T) {
+	t.Parallel()
+
+	sink := &memSink{}
+	r := NewAsync(sink, Opts{QueueSize: 1, BatchSize: 1, Policy: Block})
+
+	const n = 50
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			r.Report(xerrors.New(strconv.Itoa(i)))
+		}(i)
+	}
+
+	wg.Wait()
+
+	if err := r.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if got := len(sink.messages()); got != n {
+		t.Errorf("reported %v errors, want %v", got, n)
+	}
+
+	if got := r.Dropped(); got != 0 {
+		t.Errorf("Dropped() = %v, want 0", got)
+	}
+}
+
+func TestAsync_Close(t *testing.T) {
+	t.Parallel()
+
+	sink := &memSink{block: make(chan struct{})}
+	r := NewAsync(sink, Opts{})
+
+	r.Report(xerrors.New("test msg"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := r.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Close() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	close(sink.block)
+
+	if err := r.Close(context.Background()); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+
+	if err := r.Flush(context.Background()); err != nil {
+		t.Errorf("Flush() after Close error = %v", err)
+	}
+}
+
+func TestAsync_OnError(t *testing.T) {
+	t.Parallel()
+
+	sinkErr := errors.New("sink error")
+
+	var got error
+
+	r := NewAsync(&memSink{err: sinkErr}, Opts{
+		Filter:  func(xerrors.XError) bool { return true },
+		OnError: func(err error) { got = err },
+	})
+
+	r.Report(xhttp.NewNotFoundError(errors.New("not found")))
+
+	if err := r.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if got != sinkErr {
+		t.Errorf("OnError() called with %v, want %v", got, sinkErr)
+	}
+}
+
+func TestAsync_Sampler(t *testing.T) {
+	t.Parallel()
+
+	sink := &memSink{}
+	r := NewAsync(sink, Opts{Sampler: PerFingerprint(2, time.Hour)})
+
+	for i := 0; i < 5; i++ {
+		r.Report(xerrors.New("same"))
+	}
+
+	r.Report(xerrors.New("other"))
+
+	if err := r.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if got, want := sink.messages(), []string{"same", "same", "other"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reported %v, want %v", got, want)
+	}
+}
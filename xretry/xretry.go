@@ -0,0 +1,168 @@
+package xretry
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+const (
+	defaultMaxAttempts  = 3
+	defaultInitialDelay = 100 * time.Millisecond
+	defaultMaxDelay     = 10 * time.Second
+	defaultMultiplier   = 2
+)
+
+// Clock waits between attempts, it allows to replace real time in tests.
+type Clock interface {
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Policy configures retries of Do. Zero fields are replaced with defaults, except Jitter.
+type Policy struct {
+	// MaxAttempts is the maximum number of calls including the first one, 3 by default.
+	MaxAttempts int
+	// InitialDelay is the delay before the first retry, 100ms by default.
+	InitialDelay time.Duration
+	// MaxDelay caps exponential backoff delay, 10s by default.
+	// Delay hint from XError RetryAfter is respected even if it's longer.
+	MaxDelay time.Duration
+	// Multiplier increases delay after each attempt, 2 by default.
+	Multiplier float64
+	// Jitter randomly reduces delay by up to Jitter fraction of it, it's clamped to [0, 1].
+	Jitter float64
+
+	// Clock is used to wait between attempts, real time by default.
+	Clock Clock
+	// Rand returns pseudo-random number in [0, 1) used for jitter, math/rand by default.
+	Rand func() float64
+}
+
+// DefaultPolicy returns policy with default values and 20% jitter.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:  defaultMaxAttempts,
+		InitialDelay: defaultInitialDelay,
+		MaxDelay:     defaultMaxDelay,
+		Multiplier:   defaultMultiplier,
+		Jitter:       0.2, // nolint:gomnd
+		Clock:        realClock{},
+		Rand:         rand.Float64, // nolint:gosec
+	}
+}
+
+// Do calls fn until it succeeds, returns non-retryable error, attempts are exhausted or ctx is done.
+// Delay between attempts grows exponentially with jitter, RetryAfter hint of the error is respected.
+// It returns nil if fn succeeded, otherwise errors of all attempts,
+// followed by context error if ctx is done.
+func Do(ctx context.Context, fn func() xerrors.XError, policy Policy) *xerrors.XErrs {
+	policy = policy.withDefaults()
+	xErrs := xerrors.NewXErrs()
+
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			xErrs.Add(xerrors.FromContext(ctx, err))
+
+			return xErrs
+		}
+
+		xErr := fn()
+		if xerrors.IsNil(xErr) {
+			return nil
+		}
+
+		xErrs.Add(xErr)
+
+		if attempt >= policy.MaxAttempts || !retryable(xErr) {
+			return xErrs
+		}
+
+		select {
+		case <-ctx.Done():
+			xErrs.Add(xerrors.FromContext(ctx, ctx.Err()))
+
+			return xErrs
+		case <-policy.Clock.After(policy.delay(attempt, retryAfter(xErr))):
+		}
+	}
+}
+
+func (p Policy) withDefaults() Policy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultMaxAttempts
+	}
+
+	if p.InitialDelay <= 0 {
+		p.InitialDelay = defaultInitialDelay
+	}
+
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = defaultMaxDelay
+	}
+
+	if p.Multiplier <= 0 {
+		p.Multiplier = defaultMultiplier
+	}
+
+	if p.Clock == nil {
+		p.Clock = realClock{}
+	}
+
+	if p.Rand == nil {
+		p.Rand = rand.Float64 // nolint:gosec
+	}
+
+	p.Jitter = clampJitter(p.Jitter)
+
+	return p
+}
+
+// clampJitter returns jitter clamped to [0, 1], NaN is replaced with 0.
+func clampJitter(jitter float64) float64 {
+	switch {
+	case jitter > 1:
+		return 1
+	case jitter > 0:
+		return jitter
+	default:
+		return 0
+	}
+}
+
+// delay returns delay before the next attempt after attempt failed.
+func (p Policy) delay(attempt int, hint time.Duration) time.Duration {
+	backoff := float64(p.InitialDelay) * math.Pow(p.Multiplier, float64(attempt-1))
+	if backoff > float64(p.MaxDelay) {
+		backoff = float64(p.MaxDelay)
+	}
+
+	backoff -= backoff * p.Jitter * p.Rand()
+
+	if d := time.Duration(backoff); d > hint {
+		return d
+	}
+
+	return hint
+}
+
+func retryable(xErr xerrors.XError) bool {
+	r, ok := xErr.(interface{ Retryable() bool })
+
+	return ok && r.Retryable()
+}
+
+func retryAfter(xErr xerrors.XError) time.Duration {
+	if r, ok := xErr.(interface{ RetryAfter() time.Duration }); ok {
+		return r.RetryAfter()
+	}
+
+	return 0
+}
@@ -0,0 +1,225 @@
+// nolint:goerr113,funlen
+package xretry
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eugeneradionov/xerrors"
+)
+
+type fakeClock struct {
+	mu     sync.Mutex
+	delays []time.Duration
+	block  bool
+	onWait func()
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	c.delays = append(c.delays, d)
+	c.mu.Unlock()
+
+	if c.onWait != nil {
+		c.onWait()
+	}
+
+	ch := make(chan time.Time, 1)
+	if !c.block {
+		ch <- time.Time{}
+	}
+
+	return ch
+}
+
+func attempts(xErrs ...xerrors.XError) (fn func() xerrors.XError, calls func() int) {
+	n := 0
+
+	return func() xerrors.XError {
+			n++
+			if n > len(xErrs) {
+				return nil
+			}
+
+			return xErrs[n-1]
+		}, func() int {
+			return n
+		}
+}
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+
+	retryable := xerrors.New("unavailable", xerrors.WithRetryable(true))
+	retryAfter := xerrors.New("rate limited", xerrors.WithRetryAfter(time.Second))
+	permanent := xerrors.New("bad request")
+
+	tests := []struct {
+		name       string
+		xErrs      []xerrors.XError
+		policy     Policy
+		want       *xerrors.XErrs
+		wantCalls  int
+		wantDelays []time.Duration
+	}{
+		{
+			name:       "success",
+			xErrs:      nil,
+			policy:     Policy{},
+			want:       nil,
+			wantCalls:  1,
+			wantDelays: nil,
+		},
+		{
+			name:       "typed nil is success",
+			xErrs:      []xerrors.XError{(*xerrors.XErr)(nil)},
+			policy:     Policy{},
+			want:       nil,
+			wantCalls:  1,
+			wantDelays: nil,
+		},
+		{
+			name:       "success after retries",
+			xErrs:      []xerrors.XError{retryable, retryable},
+			policy:     Policy{MaxAttempts: 3},
+			want:       nil,
+			wantCalls:  3,
+			wantDelays: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name:       "not retryable",
+			xErrs:      []xerrors.XError{retryable, permanent, retryable},
+			policy:     Policy{MaxAttempts: 5},
+			want:       &xerrors.XErrs{Errs: []xerrors.XError{retryable, permanent}},
+			wantCalls:  2,
+			wantDelays: []time.Duration{100 * time.Millisecond},
+		},
+		{
+			name:       "attempts exhausted",
+			xErrs:      []xerrors.XError{retryable, retryable, retryable},
+			policy:     Policy{MaxAttempts: 2, InitialDelay: time.Second, Multiplier: 3},
+			want:       &xerrors.XErrs{Errs: []xerrors.XError{retryable, retryable}},
+			wantCalls:  2,
+			wantDelays: []time.Duration{time.Second},
+		},
+		{
+			name:       "max delay",
+			xErrs:      []xerrors.XError{retryable, retryable, retryable},
+			policy:     Policy{MaxAttempts: 4, InitialDelay: time.Second, MaxDelay: 3 * time.Second},
+			want:       nil,
+			wantCalls:  4,
+			wantDelays: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+		},
+		{
+			name:       "retry after",
+			xErrs:      []xerrors.XError{retryAfter, retryable},
+			policy:     Policy{MaxAttempts: 3},
+			want:       nil,
+			wantCalls:  3,
+			wantDelays: []time.Duration{time.Second, 200 * time.Millisecond},
+		},
+		{
+			name:       "jitter",
+			xErrs:      []xerrors.XError{retryable},
+			policy:     Policy{Jitter: 0.5, Rand: func() float64 { return 0.5 }},
+			want:       nil,
+			wantCalls:  2,
+			wantDelays: []time.Duration{75 * time.Millisecond},
+		},
+		{
+			name:       "jitter above 1 is clamped",
+			xErrs:      []xerrors.XError{retryable, retryable},
+			policy:     Policy{Jitter: 3, Rand: func() float64 { return 0.5 }},
+			want:       nil,
+			wantCalls:  3,
+			wantDelays: []time.Duration{50 * time.Millisecond, 100 * time.Millisecond},
+		},
+		{
+			name:       "negative jitter is clamped",
+			xErrs:      []xerrors.XError{retryable},
+			policy:     Policy{Jitter: -1, Rand: func() float64 { return 0.5 }},
+			want:       nil,
+			wantCalls:  2,
+			wantDelays: []time.Duration{100 * time.Millisecond},
+		},
+		{
+			name:       "NaN jitter is ignored",
+			xErrs:      []xerrors.XError{retryable},
+			policy:     Policy{Jitter: math.NaN(), Rand: func() float64 { return 0.5 }},
+			want:       nil,
+			wantCalls:  2,
+			wantDelays: []time.Duration{100 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clock := &fakeClock{}
+			tt.policy.Clock = clock
+			fn, calls := attempts(tt.xErrs...)
+
+			if got := Do(context.Background(), fn, tt.policy); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Do() = %v, want %v", got, tt.want)
+			}
+
+			if got := calls(); got != tt.wantCalls {
+				t.Errorf("Do() calls = %v, want %v", got, tt.wantCalls)
+			}
+
+			if !reflect.DeepEqual(clock.delays, tt.wantDelays) {
+				t.Errorf("Do() delays = %v, want %v", clock.delays, tt.wantDelays)
+			}
+		})
+	}
+}
+
+func TestDo_Context(t *testing.T) {
+	t.Parallel()
+
+	retryable := xerrors.New("unavailable", xerrors.WithRetryable(true))
+
+	t.Run("canceled before call", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		fn, calls := attempts(retryable)
+		got := Do(ctx, fn, Policy{Clock: &fakeClock{}})
+
+		if calls() != 0 {
+			t.Errorf("Do() calls = %v, want 0", calls())
+		}
+
+		if got.Len() != 1 || !errors.Is(got, context.Canceled) {
+			t.Errorf("Do() = %v, want context canceled", got)
+		}
+	})
+
+	t.Run("canceled while waiting", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		fn, calls := attempts(retryable, retryable)
+		got := Do(ctx, fn, Policy{Clock: &fakeClock{block: true, onWait: cancel}})
+
+		if calls() != 1 {
+			t.Errorf("Do() calls = %v, want 1", calls())
+		}
+
+		if got.Len() != 2 || got.First() != retryable || !errors.Is(got, context.Canceled) {
+			t.Errorf("Do() = %v, want retryable error and context canceled", got)
+		}
+	})
+}
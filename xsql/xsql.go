@@ -0,0 +1,95 @@
+package xsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+
+	"github.com/eugeneradionov/xerrors"
+	"github.com/eugeneradionov/xerrors/xhttp"
+)
+
+// SQLSTATE codes of integrity constraint violations.
+const (
+	SQLStateForeignKeyViolation = "23503"
+	SQLStateUniqueViolation     = "23505"
+)
+
+// SQLStater is implemented by driver errors that expose SQLSTATE code, e.g. pgconn.PgError and pq.Error.
+type SQLStater interface {
+	SQLState() string
+}
+
+// Hook maps driver-specific error into XErr, it returns nil if err is not recognized.
+type Hook func(err error, opts ...xerrors.XErrOpt) *xerrors.XErr
+
+// Opts configures Map.
+type Opts struct {
+	// Ctx is used to map context errors with xerrors.FromContext, context.Background is used if nil.
+	Ctx context.Context
+	// Hooks are called in order before built-in mapping, the first non-nil result is returned.
+	Hooks []Hook
+	// XErrOpts are applied to created XErr.
+	XErrOpts []xerrors.XErrOpt
+}
+
+// Map converts database/sql error into XErr:
+//   - sql.ErrNoRows into NotFound(404);
+//   - unique and foreign key violations reported via SQLStater into Conflict(409) and UnprocessableEntity(422);
+//   - driver.ErrBadConn into retryable ServiceUnavailable(503);
+//   - context cancellation and deadline with xerrors.FromContext;
+//   - sql.ErrTxDone and any other error into InternalServerError(500).
+//
+// It returns nil if err is nil.
+func Map(err error, opts Opts) *xerrors.XErr {
+	if err == nil {
+		return nil
+	}
+
+	for _, hook := range opts.Hooks {
+		if xErr := hook(err, opts.XErrOpts...); xErr != nil {
+			return xErr
+		}
+	}
+
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if xErr := xerrors.FromContext(ctx, err, opts.XErrOpts...); xErr != nil {
+		return xErr
+	}
+
+	if xErr := SQLStateHook(err, opts.XErrOpts...); xErr != nil {
+		return xErr
+	}
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return xhttp.NewNotFoundError(err, opts.XErrOpts...)
+	case errors.Is(err, driver.ErrBadConn):
+		return xhttp.NewServiceUnavailableError(err, opts.XErrOpts...)
+	default:
+		return xhttp.NewInternalServerError(err, opts.XErrOpts...)
+	}
+}
+
+// SQLStateHook maps integrity constraint violations reported via SQLStater:
+// unique violation into Conflict(409) and foreign key violation into UnprocessableEntity(422).
+func SQLStateHook(err error, opts ...xerrors.XErrOpt) *xerrors.XErr {
+	var stater SQLStater
+	if !errors.As(err, &stater) {
+		return nil
+	}
+
+	switch stater.SQLState() {
+	case SQLStateUniqueViolation:
+		return xhttp.NewConflictError(err, opts...)
+	case SQLStateForeignKeyViolation:
+		return xhttp.NewUnprocessableEntityError(err, opts...)
+	default:
+		return nil
+	}
+}
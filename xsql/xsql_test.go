@@ -0,0 +1,169 @@
+// nolint:goerr113
+package xsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/eugeneradionov/xerrors"
+	"github.com/eugeneradionov/xerrors/xhttp"
+)
+
+type pgError struct{ code string }
+
+func (e *pgError) Error() string    { return "pq: error " + e.code }
+func (e *pgError) SQLState() string { return e.code }
+
+type mysqlError struct{ number uint16 }
+
+func (e *mysqlError) Error() string { return fmt.Sprintf("Error %d", e.number) }
+
+func mysqlHook(err error, opts ...xerrors.XErrOpt) *xerrors.XErr {
+	var mysqlErr *mysqlError
+	if errors.As(err, &mysqlErr) && mysqlErr.number == 1062 {
+		return xhttp.NewConflictError(err, opts...)
+	}
+
+	return nil
+}
+
+func TestMap(t *testing.T) {
+	t.Parallel()
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name          string
+		err           error
+		opts          Opts
+		wantNil       bool
+		wantCode      int
+		wantRetryable bool
+	}{
+		{
+			name:    "nil error",
+			err:     nil,
+			wantNil: true,
+		},
+		{
+			name:     "no rows",
+			err:      fmt.Errorf("get user: %w", sql.ErrNoRows),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "tx done",
+			err:      sql.ErrTxDone,
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name:          "bad connection",
+			err:           driver.ErrBadConn,
+			wantCode:      http.StatusServiceUnavailable,
+			wantRetryable: true,
+		},
+		{
+			name:     "context canceled",
+			err:      canceledCtx.Err(),
+			opts:     Opts{Ctx: canceledCtx},
+			wantCode: xerrors.StatusClientClosedRequest,
+		},
+		{
+			name:          "deadline exceeded without context",
+			err:           fmt.Errorf("query: %w", context.DeadlineExceeded),
+			wantCode:      http.StatusGatewayTimeout,
+			wantRetryable: true,
+		},
+		{
+			name:     "unique violation",
+			err:      fmt.Errorf("create user: %w", &pgError{code: SQLStateUniqueViolation}),
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:     "foreign key violation",
+			err:      &pgError{code: SQLStateForeignKeyViolation},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "other SQLSTATE",
+			err:      &pgError{code: "42601"},
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name:     "custom hook",
+			err:      &mysqlError{number: 1062},
+			opts:     Opts{Hooks: []Hook{mysqlHook}},
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:     "custom hook not matched",
+			err:      &mysqlError{number: 1064},
+			opts:     Opts{Hooks: []Hook{mysqlHook}},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := Map(tt.err, tt.opts)
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("Map() = %v, want nil", got)
+				}
+
+				return
+			}
+
+			if code := xhttp.StatusCode(got); code != tt.wantCode {
+				t.Errorf("Map() status code = %v, want %v", code, tt.wantCode)
+			}
+
+			if got.Retryable() != tt.wantRetryable {
+				t.Errorf("Map() Retryable() = %v, want %v", got.Retryable(), tt.wantRetryable)
+			}
+
+			if !errors.Is(got, tt.err) {
+				t.Errorf("Map() cause = %v, want %v", got.Unwrap(), tt.err)
+			}
+		})
+	}
+}
+
+func TestMap_XErrOpts(t *testing.T) {
+	t.Parallel()
+
+	const code = "xsql_xerropts_test"
+
+	var seen int32
+
+	// options must be applied before OnNew hooks are run.
+	t.Cleanup(xerrors.OnNew(func(xErr *xerrors.XErr) {
+		if xErr.GetCode() == code {
+			atomic.AddInt32(&seen, 1)
+		}
+	}))
+
+	opts := Opts{XErrOpts: []xerrors.XErrOpt{xerrors.WithCode(code)}}
+
+	if got := Map(sql.ErrNoRows, opts).GetCode(); got != code {
+		t.Errorf("Map() code = %v, want %v", got, code)
+	}
+
+	if got := Map(context.Canceled, opts).GetCode(); got != code {
+		t.Errorf("Map() code = %v, want %v", got, code)
+	}
+
+	if got := atomic.LoadInt32(&seen); got != 2 {
+		t.Errorf("OnNew hook saw code %v times, want 2", got)
+	}
+}